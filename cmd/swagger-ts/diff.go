@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gopkg-dev/swagger-ts-gen/pkg/swaggerts"
+)
+
+func newDiffCommand(s *settings) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "diff <old> <new>",
+		Short: "Compare the API generated from two specs and fail on breaking changes",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Both specs go through the same input resolution and validation as repeated -i flags.
+			s.inputFlags = args
+			cfg, logf, err := s.resolve(cmd)
+			if err != nil {
+				return err
+			}
+			if len(s.inputs) != 2 {
+				return fmt.Errorf("diff needs two specs, got %d", len(s.inputs))
+			}
+
+			sources, _, err := s.loadSources(cmd.Context(), logf)
+			if err != nil {
+				return err
+			}
+			changes, err := swaggerts.DiffAPI(sources[0], sources[1], s.generatorOptions(cfg, logf, true))
+			if err != nil {
+				return err
+			}
+			if err := swaggerts.WriteAPIChanges(cmd.OutOrStdout(), format, changes); err != nil {
+				return err
+			}
+			if breaking := swaggerts.BreakingAPIChanges(changes); breaking > 0 {
+				return fmt.Errorf("%d breaking change(s)", breaking)
+			}
+			return nil
+		},
+	}
+
+	s.addGroupByFlag(cmd.Flags())
+	cmd.Flags().StringVar(&format, "format", "text", "output format: "+strings.Join(swaggerts.APIChangeFormats(), ", "))
+	return cmd
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gopkg-dev/swagger-ts-gen/pkg/swaggerts"
+)
+
+const diffSpecTemplate = `openapi: 3.0.3
+info: {title: t, version: '1'}
+paths:
+  /api/v1/users:
+    get:
+      operationId: listUsers
+      parameters:
+%s
+      responses:
+        '200':
+          description: ok
+`
+
+func TestDiffCommand_MatchesPublicAPI(t *testing.T) {
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "old.yaml")
+	newPath := filepath.Join(dir, "new.yaml")
+	writeTestFile(t, oldPath, strings.Replace(diffSpecTemplate, "%s", "        - {name: keyword, in: query, schema: {type: string}}", 1))
+	writeTestFile(t, newPath, strings.Replace(diffSpecTemplate, "%s", "        - {name: keyword, in: query, required: true, schema: {type: string}}", 1))
+	configPath := filepath.Join(dir, "swagger-ts.config.yaml")
+	writeTestFile(t, configPath, "version: 1\noutput: "+filepath.Join(dir, "api")+"\n")
+
+	cmd := newRootCommand()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"diff", oldPath, newPath, "-c", configPath, "--format", "json"})
+	err := cmd.ExecuteContext(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "breaking change") {
+		t.Fatalf("a newly required query param should be breaking, got %v\n%s", err, out.String())
+	}
+
+	var sources []swaggerts.Source
+	for _, path := range []string{oldPath, newPath} {
+		spec, meta, err := swaggerts.Load(context.Background(), path, swaggerts.LoadOptions{})
+		if err != nil {
+			t.Fatalf("Load returned error: %v", err)
+		}
+		sources = append(sources, swaggerts.Source{Spec: spec, Name: meta.Source})
+	}
+	changes, err := swaggerts.DiffAPI(sources[0], sources[1], swaggerts.Options{})
+	if err != nil {
+		t.Fatalf("DiffAPI returned error: %v", err)
+	}
+	var want bytes.Buffer
+	if err := swaggerts.WriteAPIChanges(&want, "json", changes); err != nil {
+		t.Fatalf("WriteAPIChanges returned error: %v", err)
+	}
+	if out.String() != want.String() {
+		t.Fatalf("diff command and DiffAPI disagree:\ncommand:\n%s\nDiffAPI:\n%s", out.String(), want.String())
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/gopkg-dev/swagger-ts-gen/internal/config"
+	"github.com/gopkg-dev/swagger-ts-gen/pkg/swaggerts"
+)
+
+// addSourceFlags registers the config, input and fetch flags that every command shares.
+func (s *settings) addSourceFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&s.configPath, "config", "c", "", "config file path (default: discover "+strings.Join(config.FileNames, ", ")+" from the working directory upwards)")
+	flags.StringArrayVarP(&s.inputFlags, "input", "i", nil, "Swagger/OpenAPI json or yaml file path, URL, or - to read from stdin (repeatable to merge several specs)")
+	flags.BoolVarP(&s.verbose, "verbose", "v", false, "enable verbose logging")
+	flags.StringArrayVar(&s.headers, "header", nil, "extra request header for URL inputs as 'Name: value' (repeatable, $VAR expanded)")
+	flags.StringVar(&s.basicAuth, "basic-auth", "", "basic auth for URL inputs as 'user:password' ($VAR expanded)")
+	flags.StringVar(&s.bearerToken, "bearer-token", "", "bearer token for URL inputs ($VAR expanded)")
+	flags.StringVar(&s.caFile, "ca-file", "", "PEM CA bundle trusted in addition to the system roots for URL inputs")
+	flags.BoolVar(&s.insecure, "insecure", false, "skip TLS certificate verification for URL inputs")
+	flags.StringVar(&s.proxy, "proxy", "", "proxy URL for URL inputs (default: HTTP_PROXY/HTTPS_PROXY)")
+	flags.DurationVar(&s.timeout, "timeout", 20*time.Second, "request timeout for URL inputs")
+	flags.Int64Var(&s.maxBodySize, "max-body-size", 0, "maximum spec response size in bytes for URL inputs (0: unlimited)")
+}
+
+// addGeneratorFlags registers the flags that shape the written output, for the commands that
+// generate: the root command and watch.
+func (s *settings) addGeneratorFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&s.output, "output", "o", "output", "output directory")
+	flags.StringVar(&s.goSourceDir, "go-source", "", "go source directory for AST optionality inference")
+	flags.StringVar(&s.goSourceInclude, "go-source-include", "schema,fiberx", "comma-separated go source subdirectories to scan for AST optionality inference")
+	flags.BoolVar(&s.requiredByOmitEmpty, "required-by-omitempty", false, "default object fields to required, only omitempty fields are optional (requires --go-source)")
+	flags.BoolVar(&s.cleanOutput, "clean-output", true, "remove stale generated group directories and files in output path before generation")
+	s.addGroupByFlag(flags)
+	flags.BoolVar(&s.dedupeCrossGroupModels, "dedupe-cross-group-models", false, "deduplicate repeated models across groups by re-exporting from a canonical group")
+	flags.StringVar(&s.enumStyle, "enum-style", swaggerts.EnumStyleConst, "named enums: const (union type plus as const object), enum (TS enum) or union (literal union only)")
+	flags.BoolVar(&s.int64AsString, "int64-as-string", false, "type int64 fields as string, for backends that serialize them as strings (e.g. json:\",string\"); overrides scalars.int64")
+	flags.BoolVar(&s.typeGuards, "type-guards", false, "emit an isXxx type guard per variant of named discriminated unions")
+	flags.BoolVar(&s.manifest, "manifest", false, "write "+swaggerts.ManifestFile+" and record API changes since the previous run in "+swaggerts.ChangelogFile)
+	flags.BoolVar(&s.strict, "strict", false, "fail generation when any schema falls back to any (unresolved refs, untyped schemas)")
+}
+
+// addGroupByFlag registers --group-by, which lint and diff need to name functions the way a
+// generation run does.
+func (s *settings) addGroupByFlag(flags *pflag.FlagSet) {
+	flags.StringVar(&s.groupBy, "group-by", swaggerts.GroupByPath, "grouping strategy: path (first segment after /api/vN) or tag (first operation tag)")
+}
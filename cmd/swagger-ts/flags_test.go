@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/gopkg-dev/swagger-ts-gen/internal/config"
+)
+
+func TestGeneratorOptions_Int64AsStringOverridesScalars(t *testing.T) {
+	cfg := &config.Config{Scalars: map[string]string{"int64": "bigint", "decimal": "string"}}
+
+	opts := (&settings{}).generatorOptions(cfg, nil, false)
+	if opts.Scalars["int64"] != "bigint" {
+		t.Fatalf("without the flag the configured int64 mapping should be kept, got %q", opts.Scalars["int64"])
+	}
+
+	opts = (&settings{int64AsString: true}).generatorOptions(cfg, nil, false)
+	if opts.Scalars["int64"] != "string" || opts.Scalars["decimal"] != "string" {
+		t.Fatalf("--int64-as-string should map int64 to string and keep other formats: %v", opts.Scalars)
+	}
+	if cfg.Scalars["int64"] != "bigint" {
+		t.Fatalf("the flag should not modify the loaded config: %v", cfg.Scalars)
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gopkg-dev/swagger-ts-gen/pkg/swaggerts"
+)
+
+// failOnNone disables the failure threshold of the lint command.
+const failOnNone = "none"
+
+func newLintCommand(s *settings) *cobra.Command {
+	var format string
+	var severity string
+	var failOn string
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check the spec for problems that degrade the generated client",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, logf, err := s.resolve(cmd)
+			if err != nil {
+				return err
+			}
+			if cfg != nil && cfg.Lint != nil && cfg.Lint.FailOn != "" && !cmd.Flags().Changed("fail-on") {
+				failOn = cfg.Lint.FailOn
+			}
+
+			minimum, err := swaggerts.ParseLintSeverity(severity)
+			if err != nil || minimum == swaggerts.LintSeverityOff {
+				return fmt.Errorf("invalid --severity %q (expected info, warning or error)", severity)
+			}
+			threshold := swaggerts.LintSeverityOff
+			if failOn != failOnNone {
+				threshold, err = swaggerts.ParseLintSeverity(failOn)
+				if err != nil || threshold == swaggerts.LintSeverityOff {
+					return fmt.Errorf("invalid --fail-on %q (expected info, warning, error or none)", failOn)
+				}
+			}
+
+			// Broken local refs become findings instead of failing the load.
+			s.fetch.AllowUnresolvedRefs = true
+			sources, metas, err := s.loadSources(cmd.Context(), logf)
+			if err != nil {
+				return err
+			}
+			unresolved := map[string][]swaggerts.UnresolvedRef{}
+			for _, meta := range metas {
+				unresolved[meta.Source] = meta.UnresolvedRefs
+			}
+			if format == "text" {
+				for _, meta := range metas {
+					printWarnings(meta.Warnings)
+				}
+			}
+
+			findings, err := swaggerts.Lint(sources, swaggerts.LintOptions{
+				Generator:      s.generatorOptions(cfg, logf, true),
+				Severities:     cfg.LintSeverities(),
+				UnresolvedRefs: unresolved,
+			})
+			if err != nil {
+				return err
+			}
+
+			reported := make([]swaggerts.LintFinding, 0, len(findings))
+			for _, finding := range findings {
+				if finding.Severity.AtLeast(minimum) {
+					reported = append(reported, finding)
+				}
+			}
+			if err := swaggerts.WriteLintFindings(cmd.OutOrStdout(), format, reported); err != nil {
+				return err
+			}
+
+			if threshold == swaggerts.LintSeverityOff {
+				return nil
+			}
+			failed := 0
+			for _, finding := range findings {
+				if finding.Severity.AtLeast(threshold) {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("lint failed: %d findings at or above %s", failed, threshold)
+			}
+			return nil
+		},
+	}
+
+	s.addGroupByFlag(cmd.Flags())
+	cmd.Flags().StringVar(&format, "format", "text", "output format: "+strings.Join(swaggerts.LintFormats(), ", "))
+	cmd.Flags().StringVar(&severity, "severity", string(swaggerts.LintSeverityInfo), "lowest severity to report: info, warning or error")
+	cmd.Flags().StringVar(&failOn, "fail-on", string(swaggerts.LintSeverityError), "lowest severity that fails the run: info, warning, error or none")
+	return cmd
+}
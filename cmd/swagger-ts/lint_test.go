@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLintCommand_ReportsDanglingRefs(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.yaml")
+	writeTestFile(t, specPath, `openapi: 3.0.3
+info: {title: t, version: '1'}
+paths:
+  /api/v1/users:
+    get:
+      operationId: listUsers
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Missing'}
+`)
+
+	configPath := filepath.Join(dir, "swagger-ts.config.yaml")
+	writeTestFile(t, configPath, "version: 1\noutput: "+filepath.Join(dir, "api")+"\n")
+
+	cmd := newRootCommand()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"lint", "-i", specPath, "-c", configPath, "--format", "json"})
+	err := cmd.ExecuteContext(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "lint failed") {
+		t.Fatalf("dangling ref should fail lint, got %v\n%s", err, out.String())
+	}
+
+	var report struct {
+		Findings []struct {
+			Rule    string `json:"rule"`
+			Pointer string `json:"pointer"`
+			Message string `json:"message"`
+		} `json:"findings"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("lint output is not JSON: %v\n%s", err, out.String())
+	}
+	for _, finding := range report.Findings {
+		if finding.Rule == "unresolved-ref" && strings.Contains(finding.Message, "#/components/schemas/Missing") &&
+			finding.Pointer == "/paths/~1api~1v1~1users/get/responses/200/content/application~1json/schema" {
+			return
+		}
+	}
+	t.Fatalf("missing unresolved-ref finding:\n%s", out.String())
+}
@@ -1,30 +1,76 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/spf13/cobra"
 
-	"github.com/gopkg-dev/swagger-ts-gen/internal/generator"
+	"github.com/gopkg-dev/swagger-ts-gen/internal/config"
 	"github.com/gopkg-dev/swagger-ts-gen/internal/loader"
+	"github.com/gopkg-dev/swagger-ts-gen/pkg/swaggerts"
 )
 
+var errMissingInput = errors.New("input is required: use -i or --input, or set input in the config file")
+
+// settings holds the flags shared by the commands; see flags.go for which command registers which.
+type settings struct {
+	inputFlags             []string
+	inputs                 []config.InputConfig
+	output                 string
+	verbose                bool
+	goSourceDir            string
+	goSourceInclude        string
+	requiredByOmitEmpty    bool
+	cleanOutput            bool
+	dedupeCrossGroupModels bool
+	groupBy                string
+	strict                 bool
+	manifest               bool
+	enumStyle              string
+	typeGuards             bool
+	int64AsString          bool
+	configPath             string
+
+	headers     []string
+	basicAuth   string
+	bearerToken string
+	caFile      string
+	insecure    bool
+	proxy       string
+	timeout     time.Duration
+	maxBodySize int64
+
+	fetch swaggerts.LoadOptions
+	// prefetched holds specs already downloaded by the watch poll, keyed by input.
+	prefetched map[string][]byte
+}
+
 func main() {
-	var input string
-	var output string
-	var verbose bool
-	var goSourceDir string
-	var goSourceInclude string
-	var requiredByOmitEmpty bool
-	var cleanOutput bool
-	var dedupeCrossGroupModels bool
-	var logf func(string, ...any)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := newRootCommand().ExecuteContext(ctx)
+	stop()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		if errors.Is(err, errMissingInput) {
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+}
 
-	errMissingInput := errors.New("input is required: use -i or --input")
+// newRootCommand builds the swagger-ts command with its subcommands.
+func newRootCommand() *cobra.Command {
+	var s settings
+	var check bool
 
 	rootCmd := &cobra.Command{
 		Use:           "swagger-ts",
@@ -33,72 +79,271 @@ func main() {
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if input == "" {
-				return errMissingInput
-			}
-			if requiredByOmitEmpty && goSourceDir == "" {
-				return errors.New("go source dir is required when --required-by-omitempty is enabled")
-			}
-
-			if verbose {
-				logger := log.New(os.Stderr, "[swagger-ts] ", log.LstdFlags)
-				logf = logger.Printf
-			}
-
-			if logf != nil {
-				logf("loading spec from %s", input)
-			}
-			spec, meta, err := loader.Load(input)
+			cfg, logf, err := s.resolve(cmd)
 			if err != nil {
 				return err
 			}
-			if logf != nil {
-				logf("spec loaded: %s", meta.Version)
-			}
 
-			gen := generator.New(spec, generator.Options{
-				OutputDir:              output,
-				Logf:                   logf,
-				GoSourceDir:            goSourceDir,
-				GoSourceIncludeDirs:    parseCommaSeparatedValues(goSourceInclude),
-				RequiredByOmitEmpty:    requiredByOmitEmpty,
-				CleanOutput:            cleanOutput,
-				DedupeCrossGroupModels: dedupeCrossGroupModels,
-			})
-			if logf != nil {
-				logf("generating output to %s", output)
-			}
-			report, err := gen.Generate()
+			report, metas, err := s.generate(cmd.Context(), cfg, logf, check)
 			if err != nil {
 				return err
 			}
-			if logf != nil {
-				logf("generated groups=%d operations=%d types=%d", report.Groups, report.Operations, report.Types)
-			}
 
-			fmt.Printf("Source: %s\n", meta.Source)
-			fmt.Printf("Spec: %s\n", meta.Version)
+			for _, meta := range metas {
+				fmt.Printf("Source: %s\n", meta.Source)
+				fmt.Printf("Spec: %s\n", meta.Version)
+				printWarnings(meta.Warnings)
+			}
 			fmt.Printf("Groups: %d, Operations: %d, Types: %d\n", report.Groups, report.Operations, report.Types)
+			printWarnings(report.Warnings)
+			printFallbacks(report.Fallbacks, s.verbose)
+			printAPIChanges(report.APIChanges)
+			if check {
+				return reportCheck(s.output, report.Changes)
+			}
+			fmt.Printf("Files: %d written, %d unchanged, %d deleted\n", report.Written, report.Unchanged, report.Deleted)
 			return nil
 		},
 	}
 
-	rootCmd.Flags().StringVarP(&input, "input", "i", "", "Swagger/OpenAPI json or yaml file path, or URL")
-	rootCmd.Flags().StringVarP(&output, "output", "o", "output", "output directory")
-	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "enable verbose logging")
-	rootCmd.Flags().StringVar(&goSourceDir, "go-source", "", "go source directory for AST optionality inference")
-	rootCmd.Flags().StringVar(&goSourceInclude, "go-source-include", "schema,fiberx", "comma-separated go source subdirectories to scan for AST optionality inference")
-	rootCmd.Flags().BoolVar(&requiredByOmitEmpty, "required-by-omitempty", false, "default object fields to required, only omitempty fields are optional (requires --go-source)")
-	rootCmd.Flags().BoolVar(&cleanOutput, "clean-output", true, "remove stale generated group directories in output path before generation")
-	rootCmd.Flags().BoolVar(&dedupeCrossGroupModels, "dedupe-cross-group-models", false, "deduplicate repeated models across groups by re-exporting from a canonical group")
+	s.addSourceFlags(rootCmd.PersistentFlags())
+	s.addGeneratorFlags(rootCmd.Flags())
+	rootCmd.Flags().BoolVar(&check, "check", false, "render in memory and fail if the output directory is out of date, without writing")
 
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err.Error())
-		if errors.Is(err, errMissingInput) {
-			os.Exit(2)
+	rootCmd.AddCommand(newWatchCommand(&s))
+	rootCmd.AddCommand(newLintCommand(&s))
+	rootCmd.AddCommand(newDiffCommand(&s))
+	return rootCmd
+}
+
+// resolve merges the config file into flags that were not set explicitly and validates the result.
+func (s *settings) resolve(cmd *cobra.Command) (*config.Config, func(string, ...any), error) {
+	cfg, err := loadConfig(s.configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cfg != nil {
+		flags := cmd.Flags()
+		overrideString(flags.Changed("output"), &s.output, cfg.Output)
+		overrideBool(flags.Changed("verbose"), &s.verbose, cfg.Verbose)
+		overrideString(flags.Changed("go-source"), &s.goSourceDir, cfg.GoSource)
+		if !flags.Changed("go-source-include") && len(cfg.GoSourceInclude) > 0 {
+			s.goSourceInclude = strings.Join(cfg.GoSourceInclude, ",")
 		}
-		os.Exit(1)
+		overrideBool(flags.Changed("required-by-omitempty"), &s.requiredByOmitEmpty, cfg.RequiredByOmitEmpty)
+		overrideBool(flags.Changed("clean-output"), &s.cleanOutput, cfg.CleanOutput)
+		overrideBool(flags.Changed("dedupe-cross-group-models"), &s.dedupeCrossGroupModels, cfg.DedupeCrossGroupModels)
+		overrideBool(flags.Changed("strict"), &s.strict, cfg.Strict)
+		overrideBool(flags.Changed("manifest"), &s.manifest, cfg.Manifest)
+		overrideString(flags.Changed("enum-style"), &s.enumStyle, cfg.EnumStyle)
+		overrideBool(flags.Changed("type-guards"), &s.typeGuards, cfg.TypeGuards)
+		if cfg.Grouping != nil {
+			overrideString(flags.Changed("group-by"), &s.groupBy, cfg.Grouping.Strategy)
+		}
+	}
+
+	s.inputs = resolveInputs(s.inputFlags, cfg)
+	if len(s.inputs) == 0 {
+		return nil, nil, errMissingInput
+	}
+	if err := validateInputs(s.inputs); err != nil {
+		return nil, nil, err
+	}
+	fetch, err := s.loaderOptions(cmd, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.fetch = fetch
+	if s.requiredByOmitEmpty && s.goSourceDir == "" {
+		return nil, nil, errors.New("go source dir is required when --required-by-omitempty is enabled")
+	}
+
+	var logf func(string, ...any)
+	if s.verbose {
+		logger := log.New(os.Stderr, "[swagger-ts] ", log.LstdFlags)
+		logf = logger.Printf
+	}
+	if logf != nil && cfg != nil {
+		logf("config loaded from %s", cfg.Path)
+	}
+	return cfg, logf, nil
+}
+
+// loaderOptions starts from the config fetch settings and applies explicitly passed flags on top.
+func (s *settings) loaderOptions(cmd *cobra.Command, cfg *config.Config) (swaggerts.LoadOptions, error) {
+	opts := cfg.LoaderOptions()
+	flags := cmd.Flags()
+
+	if len(s.headers) > 0 {
+		headers := make(map[string]string, len(opts.Headers)+len(s.headers))
+		for name, value := range opts.Headers {
+			headers[name] = value
+		}
+		for _, header := range s.headers {
+			name, value, ok := strings.Cut(header, ":")
+			if !ok || strings.TrimSpace(name) == "" {
+				return opts, fmt.Errorf("invalid --header %q: expected 'Name: value'", strings.TrimSpace(name))
+			}
+			headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		}
+		opts.Headers = headers
+	}
+	if flags.Changed("basic-auth") {
+		username, password, ok := strings.Cut(s.basicAuth, ":")
+		if !ok || username == "" {
+			return opts, errors.New("invalid --basic-auth: expected 'user:password'")
+		}
+		opts.BasicAuth = &swaggerts.BasicAuth{Username: username, Password: password}
+		opts.BearerToken = ""
+	}
+	if flags.Changed("bearer-token") {
+		opts.BearerToken = s.bearerToken
+		opts.BasicAuth = nil
+	}
+	if flags.Changed("basic-auth") && flags.Changed("bearer-token") {
+		return opts, errors.New("--basic-auth and --bearer-token are mutually exclusive")
+	}
+	if flags.Changed("ca-file") {
+		opts.CAFile = s.caFile
 	}
+	if flags.Changed("insecure") {
+		opts.Insecure = s.insecure
+	}
+	if flags.Changed("proxy") {
+		opts.Proxy = s.proxy
+	}
+	if flags.Changed("timeout") || opts.Timeout == 0 {
+		opts.Timeout = s.timeout
+	}
+	if flags.Changed("max-body-size") {
+		if s.maxBodySize < 0 {
+			return opts, errors.New("--max-body-size must not be negative")
+		}
+		opts.MaxBodySize = s.maxBodySize
+	}
+	return opts, nil
+}
+
+// generate loads the specs and runs one generation pass.
+func (s *settings) generate(ctx context.Context, cfg *config.Config, logf func(string, ...any), check bool) (*swaggerts.Report, []*swaggerts.Meta, error) {
+	sources, metas, err := s.loadSources(ctx, logf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if logf != nil {
+		logf("generating output to %s", s.output)
+	}
+	report, err := swaggerts.Generate(ctx, sources, s.generatorOptions(cfg, logf, check))
+	if err != nil {
+		return nil, nil, err
+	}
+	if logf != nil {
+		logf("generated groups=%d operations=%d types=%d", report.Groups, report.Operations, report.Types)
+	}
+	return report, metas, nil
+}
+
+// loadSources loads every input spec in order.
+func (s *settings) loadSources(ctx context.Context, logf func(string, ...any)) ([]swaggerts.Source, []*swaggerts.Meta, error) {
+	sources := make([]swaggerts.Source, 0, len(s.inputs))
+	metas := make([]*swaggerts.Meta, 0, len(s.inputs))
+	for _, input := range s.inputs {
+		if logf != nil {
+			logf("loading spec from %s", loader.RedactSource(input.Input))
+		}
+		var (
+			spec *openapi3.T
+			meta *swaggerts.Meta
+			err  error
+		)
+		if data, ok := s.prefetched[input.Input]; ok {
+			spec, meta, err = swaggerts.LoadData(ctx, input.Input, data, s.fetch)
+		} else {
+			spec, meta, err = swaggerts.Load(ctx, input.Input, s.fetch)
+		}
+		if err != nil {
+			if len(s.inputs) > 1 {
+				return nil, nil, fmt.Errorf("%s: %w", loader.RedactSource(input.Input), err)
+			}
+			return nil, nil, err
+		}
+		if logf != nil {
+			logf("spec loaded: %s", meta.Version)
+		}
+		sources = append(sources, swaggerts.Source{
+			Spec:      spec,
+			Name:      meta.Source,
+			Namespace: input.Namespace,
+			BaseURL:   input.BaseURL,
+		})
+		metas = append(metas, meta)
+	}
+	return sources, metas, nil
+}
+
+// generatorOptions builds the generator options from the resolved flags and the config file.
+func (s *settings) generatorOptions(cfg *config.Config, logf func(string, ...any), check bool) swaggerts.Options {
+	opts := swaggerts.Options{
+		OutputDir:              s.output,
+		Logf:                   logf,
+		GoSourceDir:            s.goSourceDir,
+		GoSourceIncludeDirs:    parseCommaSeparatedValues(s.goSourceInclude),
+		RequiredByOmitEmpty:    s.requiredByOmitEmpty,
+		CleanOutput:            s.cleanOutput,
+		DedupeCrossGroupModels: s.dedupeCrossGroupModels,
+		Grouping:               swaggerts.Grouping{Strategy: s.groupBy},
+		Check:                  check,
+		Strict:                 s.strict,
+		Manifest:               s.manifest,
+		EnumStyle:              s.enumStyle,
+		TypeGuards:             s.typeGuards,
+	}
+	cfg.ApplyTo(&opts)
+	if s.int64AsString {
+		scalars := map[string]string{"int64": "string"}
+		for format, tsType := range opts.Scalars {
+			if format != "int64" {
+				scalars[format] = tsType
+			}
+		}
+		opts.Scalars = scalars
+	}
+	return opts
+}
+
+// resolveInputs prefers explicit -i flags, then the config inputs list, then the config input.
+func resolveInputs(flagInputs []string, cfg *config.Config) []config.InputConfig {
+	var inputs []config.InputConfig
+	for _, input := range flagInputs {
+		if trimmed := strings.TrimSpace(input); trimmed != "" {
+			inputs = append(inputs, config.InputConfig{Input: trimmed})
+		}
+	}
+	if len(inputs) > 0 || cfg == nil {
+		return inputs
+	}
+	if len(cfg.Inputs) > 0 {
+		return cfg.Inputs
+	}
+	if cfg.Input != "" {
+		return []config.InputConfig{{Input: cfg.Input}}
+	}
+	return nil
+}
+
+// validateInputs rejects reading stdin more than once.
+func validateInputs(inputs []config.InputConfig) error {
+	stdinCount := 0
+	for _, input := range inputs {
+		if strings.TrimSpace(input.Input) == swaggerts.StdinInput {
+			stdinCount++
+		}
+	}
+	if stdinCount > 1 {
+		return errors.New("stdin (-) can be used for at most one input")
+	}
+	return nil
 }
 
 func parseCommaSeparatedValues(input string) []string {
@@ -116,3 +361,80 @@ func parseCommaSeparatedValues(input string) []string {
 	}
 	return values
 }
+
+// loadConfig loads an explicit config file, or discovers one when no path is given.
+// A nil config without error means no config file is in use.
+func loadConfig(path string) (*config.Config, error) {
+	if path == "" {
+		discovered, err := config.Discover(".")
+		if err != nil {
+			return nil, err
+		}
+		if discovered == "" {
+			return nil, nil
+		}
+		path = discovered
+	}
+	return config.Load(path)
+}
+
+// reportCheck prints the files that differ from the rendered output and fails when there are any.
+func reportCheck(outputDir string, changes []swaggerts.FileChange) error {
+	if len(changes) == 0 {
+		fmt.Printf("Output %s is up to date\n", outputDir)
+		return nil
+	}
+
+	counts := map[swaggerts.ChangeKind]int{}
+	fmt.Printf("Output %s is out of date:\n", outputDir)
+	for _, change := range changes {
+		counts[change.Kind]++
+		fmt.Printf("  %-8s %s\n", change.Kind, change.Path)
+	}
+	return fmt.Errorf("output is out of date: %d added, %d removed, %d changed (run without --check to regenerate)",
+		counts[swaggerts.ChangeAdded], counts[swaggerts.ChangeRemoved], counts[swaggerts.ChangeChanged])
+}
+
+// printWarnings reports schema constructs that were ignored or fell back to any.
+func printWarnings(warnings []string) {
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+	}
+}
+
+// printFallbacks summarizes the schemas emitted as any; verbose runs list every one.
+func printFallbacks(fallbacks []swaggerts.Fallback, verbose bool) {
+	if len(fallbacks) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "warning: %d schema(s) fell back to any (use --strict to fail)\n", len(fallbacks))
+	if !verbose {
+		return
+	}
+	for _, fallback := range fallbacks {
+		fmt.Fprintf(os.Stderr, "  %s\n", fallback)
+	}
+}
+
+// printAPIChanges summarizes the manifest diff written to the changelog.
+func printAPIChanges(changes []swaggerts.APIChange) {
+	if len(changes) == 0 {
+		return
+	}
+	breaking := swaggerts.BreakingAPIChanges(changes)
+	fmt.Printf("API changes: %d breaking, %d non-breaking (see %s)\n", breaking, len(changes)-breaking, swaggerts.ChangelogFile)
+}
+
+func overrideString(flagChanged bool, target *string, value string) {
+	if flagChanged || value == "" {
+		return
+	}
+	*target = value
+}
+
+func overrideBool(flagChanged bool, target *bool, value *bool) {
+	if flagChanged || value == nil {
+		return
+	}
+	*target = *value
+}
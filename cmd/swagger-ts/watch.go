@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gopkg-dev/swagger-ts-gen/internal/config"
+	"github.com/gopkg-dev/swagger-ts-gen/internal/generator"
+	"github.com/gopkg-dev/swagger-ts-gen/internal/loader"
+	"github.com/gopkg-dev/swagger-ts-gen/pkg/swaggerts"
+)
+
+// maxListedChanges caps the per-file lines printed after each regeneration.
+const maxListedChanges = 10
+
+func newWatchCommand(s *settings) *cobra.Command {
+	var interval time.Duration
+	var debounce time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Regenerate whenever a spec (file or URL), a file it refers to, the config file or the --go-source directory changes",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pristine := *s
+			cfg, logf, err := s.resolve(cmd)
+			if err != nil {
+				return err
+			}
+			for _, input := range s.inputs {
+				if strings.TrimSpace(input.Input) == loader.StdinInput {
+					return errors.New("watch cannot read the spec from stdin: pass a file path or URL")
+				}
+			}
+			if interval <= 0 || debounce < 0 {
+				return fmt.Errorf("--interval must be positive and --debounce must not be negative")
+			}
+
+			// Reloading starts from the flag values so keys removed from the config take effect.
+			reload := func() (*config.Config, func(string, ...any), error) {
+				resolved := *s
+				*s = pristine
+				cfg, logf, err := s.resolve(cmd)
+				if err != nil {
+					*s = resolved
+				}
+				return cfg, logf, err
+			}
+			w := &watcher{settings: s, cfg: cfg, logf: logf, reload: reload, interval: interval, debounce: debounce}
+			return w.run(cmd.Context())
+		},
+	}
+
+	s.addGeneratorFlags(cmd.Flags())
+	cmd.Flags().DurationVar(&interval, "interval", time.Second, "how often to poll the spec and go sources for changes")
+	cmd.Flags().DurationVar(&debounce, "debounce", 500*time.Millisecond, "quiet period after the last change before regenerating")
+	return cmd
+}
+
+// watcher polls the inputs of a generation run and regenerates after changes settle.
+// Polling keeps it dependency-free and works the same for local files and URLs.
+type watcher struct {
+	settings *settings
+	cfg      *config.Config
+	logf     func(string, ...any)
+	// reload resolves the settings again after the config file changed.
+	reload   func() (*config.Config, func(string, ...any), error)
+	interval time.Duration
+	debounce time.Duration
+
+	specRevisions  map[string]loader.Revision
+	refRevisions   map[string]loader.Revision
+	configRevision loader.Revision
+	configChanged  bool
+	sourceRevision string
+}
+
+func (w *watcher) run(ctx context.Context) error {
+	if _, err := w.poll(); err != nil {
+		return err
+	}
+	w.configChanged = false
+	w.regenerate(ctx)
+	watched := make([]string, 0, len(w.settings.inputs))
+	for _, input := range w.settings.inputs {
+		watched = append(watched, loader.RedactSource(input.Input))
+	}
+	fmt.Printf("Watching %s", strings.Join(watched, ", "))
+	if w.settings.goSourceDir != "" {
+		fmt.Printf(" and %s", w.settings.goSourceDir)
+	}
+	fmt.Println(" (Ctrl+C to stop)")
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	pending := &debouncer{delay: w.debounce}
+	var debounceC <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			pending.stop()
+			return nil
+		case <-ticker.C:
+			changed, err := w.poll()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "[%s] %v\n", timestamp(), err)
+				continue
+			}
+			if len(changed) == 0 {
+				continue
+			}
+			if w.logf != nil {
+				w.logf("change detected in %s", strings.Join(changed, ", "))
+			}
+			debounceC = pending.trigger()
+		case <-debounceC:
+			debounceC = nil
+			w.regenerate(ctx)
+		}
+	}
+}
+
+// poll refreshes the stored revisions and returns the inputs that changed since the last poll.
+func (w *watcher) poll() ([]string, error) {
+	var changed []string
+
+	if w.specRevisions == nil {
+		w.specRevisions = map[string]loader.Revision{}
+	}
+	for _, input := range w.settings.inputs {
+		revision, specChanged, err := loader.Poll(input.Input, w.specRevisions[input.Input], w.settings.fetch)
+		if err != nil {
+			return nil, err
+		}
+		w.specRevisions[input.Input] = revision
+		if specChanged {
+			changed = append(changed, loader.RedactSource(input.Input))
+		}
+	}
+
+	for _, path := range mapKeysSorted(w.refRevisions) {
+		prev := w.refRevisions[path]
+		revision, refChanged, err := loader.Poll(path, prev, loader.Options{})
+		if err != nil {
+			// A removed ref file is a change once; the next generation reports the error.
+			revision, refChanged = loader.Revision{}, !prev.ModTime.IsZero()
+		}
+		w.refRevisions[path] = revision
+		if refChanged {
+			changed = append(changed, path)
+		}
+	}
+
+	if w.cfg != nil && w.cfg.Path != "" {
+		revision, configChanged, err := loader.Poll(w.cfg.Path, w.configRevision, loader.Options{})
+		if err != nil {
+			return nil, err
+		}
+		w.configRevision = revision
+		if configChanged {
+			w.configChanged = true
+			changed = append(changed, w.cfg.Path)
+		}
+	}
+
+	if w.settings.goSourceDir != "" {
+		sourceRevision, err := goSourceRevision(w.settings.goSourceDir, parseCommaSeparatedValues(w.settings.goSourceInclude))
+		if err != nil {
+			return nil, err
+		}
+		if sourceRevision != w.sourceRevision {
+			w.sourceRevision = sourceRevision
+			changed = append(changed, w.settings.goSourceDir)
+		}
+	}
+	return changed, nil
+}
+
+// regenerate runs one generation pass and prints a short summary; errors are reported
+// without stopping the watch so the next fix can be picked up.
+func (w *watcher) regenerate(ctx context.Context) {
+	if w.configChanged {
+		w.configChanged = false
+		cfg, logf, err := w.reload()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[%s] reload config failed: %v\n", timestamp(), err)
+			return
+		}
+		w.cfg, w.logf = cfg, logf
+	}
+
+	started := time.Now()
+	w.settings.prefetched = w.fetchedSpecs()
+	report, metas, err := w.settings.generate(ctx, w.cfg, w.logf, false)
+	w.settings.prefetched = nil
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[%s] generation failed: %v\n", timestamp(), err)
+		return
+	}
+	w.trackRefFiles(metas)
+	fmt.Println(formatWatchSummary(report, time.Since(started)))
+	printWarnings(report.Warnings)
+	printFallbacks(report.Fallbacks, w.settings.verbose)
+	printAPIChanges(report.APIChanges)
+}
+
+// fetchedSpecs returns the URL specs the last poll downloaded, keyed by input, so generation
+// does not fetch them again.
+func (w *watcher) fetchedSpecs() map[string][]byte {
+	fetched := map[string][]byte{}
+	for input, revision := range w.specRevisions {
+		if revision.Body != nil {
+			fetched[input] = revision.Body
+		}
+	}
+	return fetched
+}
+
+// trackRefFiles starts polling the local files the specs refer to and stops polling files
+// they no longer refer to.
+func (w *watcher) trackRefFiles(metas []*swaggerts.Meta) {
+	next := map[string]loader.Revision{}
+	for _, meta := range metas {
+		for _, path := range meta.Files {
+			if revision, ok := w.refRevisions[path]; ok {
+				next[path] = revision
+				continue
+			}
+			if revision, _, err := loader.Poll(path, loader.Revision{}, loader.Options{}); err == nil {
+				next[path] = revision
+			}
+		}
+	}
+	w.refRevisions = next
+}
+
+// debouncer delays a regeneration until no change arrived for delay.
+type debouncer struct {
+	delay time.Duration
+	timer *time.Timer
+}
+
+// trigger restarts the quiet period and returns the channel that fires when it ends.
+func (d *debouncer) trigger() <-chan time.Time {
+	if d.timer == nil {
+		d.timer = time.NewTimer(d.delay)
+	} else {
+		d.timer.Reset(d.delay)
+	}
+	return d.timer.C
+}
+
+func (d *debouncer) stop() {
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+}
+
+func formatWatchSummary(report *swaggerts.Report, elapsed time.Duration) string {
+	counts := map[swaggerts.ChangeKind]int{}
+	for _, change := range report.Changes {
+		counts[change.Kind]++
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] regenerated in %s: %d added, %d changed, %d removed, %d unchanged",
+		timestamp(), elapsed.Round(time.Millisecond),
+		counts[swaggerts.ChangeAdded], counts[swaggerts.ChangeChanged], counts[swaggerts.ChangeRemoved], report.Unchanged)
+	for idx, change := range report.Changes {
+		if idx == maxListedChanges {
+			fmt.Fprintf(&b, "\n  ... %d more", len(report.Changes)-maxListedChanges)
+			break
+		}
+		fmt.Fprintf(&b, "\n  %-8s %s", change.Kind, change.Path)
+	}
+	return b.String()
+}
+
+// goSourceRevision fingerprints the Go files the AST optionality scan reads, those under dir
+// below one of includeDirs, by path, size and modification time.
+func goSourceRevision(dir string, includeDirs []string) (string, error) {
+	files, err := generator.GoSourceFiles(dir, includeDirs)
+	if err != nil {
+		return "", fmt.Errorf("scan go source dir failed: %w", err)
+	}
+	var b strings.Builder
+	for _, path := range files {
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", fmt.Errorf("scan go source dir failed: %w", err)
+		}
+		fmt.Fprintf(&b, "%s:%d:%d\n", path, info.Size(), info.ModTime().UnixNano())
+	}
+	return b.String(), nil
+}
+
+// mapKeysSorted returns the keys of values in order, so polling reports changes deterministically.
+func mapKeysSorted[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func timestamp() string {
+	return time.Now().Format("15:04:05")
+}
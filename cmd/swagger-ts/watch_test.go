@@ -0,0 +1,115 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gopkg-dev/swagger-ts-gen/internal/config"
+	"github.com/gopkg-dev/swagger-ts-gen/pkg/swaggerts"
+)
+
+func writeTestFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("create dir for %s failed: %v", path, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s failed: %v", path, err)
+	}
+}
+
+// touch moves the modification time forward so polling sees a change on coarse file systems.
+func touch(t *testing.T, path string, content string) {
+	t.Helper()
+	writeTestFile(t, path, content)
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("touch %s failed: %v", path, err)
+	}
+}
+
+func TestGoSourceRevision_TracksOnlyIncludedDirs(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "schema", "user.go"), "package schema\n")
+	writeTestFile(t, filepath.Join(dir, "handler", "user.go"), "package handler\n")
+
+	first, err := goSourceRevision(dir, []string{"schema"})
+	if err != nil {
+		t.Fatalf("goSourceRevision returned error: %v", err)
+	}
+	if !strings.Contains(first, filepath.Join(dir, "schema", "user.go")) || strings.Contains(first, "handler") {
+		t.Fatalf("revision should cover only the included dir:\n%s", first)
+	}
+
+	touch(t, filepath.Join(dir, "handler", "user.go"), "package handler\n\nvar x int\n")
+	if second, _ := goSourceRevision(dir, []string{"schema"}); second != first {
+		t.Fatalf("changes outside the included dirs should not change the revision")
+	}
+
+	touch(t, filepath.Join(dir, "schema", "user.go"), "package schema\n\ntype User struct{}\n")
+	if third, _ := goSourceRevision(dir, []string{"schema"}); third == first {
+		t.Fatalf("changes inside the included dirs should change the revision")
+	}
+}
+
+func TestWatcherPoll_ReportsRefAndConfigChanges(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.yaml")
+	refPath := filepath.Join(dir, "schemas", "user.yaml")
+	configPath := filepath.Join(dir, "swagger-ts.yaml")
+	writeTestFile(t, specPath, "openapi: 3.0.3\n")
+	writeTestFile(t, refPath, "type: object\n")
+	writeTestFile(t, configPath, "output: api\n")
+
+	w := &watcher{
+		settings: &settings{inputs: []config.InputConfig{{Input: specPath}}},
+		cfg:      &config.Config{Path: configPath},
+	}
+	if _, err := w.poll(); err != nil {
+		t.Fatalf("first poll returned error: %v", err)
+	}
+	w.configChanged = false
+	w.trackRefFiles([]*swaggerts.Meta{{Files: []string{refPath}}})
+	if changed, err := w.poll(); err != nil || len(changed) != 0 {
+		t.Fatalf("untouched inputs should not be reported: changed=%v err=%v", changed, err)
+	}
+
+	touch(t, refPath, "type: object\nproperties: {}\n")
+	if changed, err := w.poll(); err != nil || len(changed) != 1 || changed[0] != refPath {
+		t.Fatalf("ref file change should be reported: changed=%v err=%v", changed, err)
+	}
+
+	touch(t, configPath, "output: src/api\n")
+	changed, err := w.poll()
+	if err != nil || len(changed) != 1 || changed[0] != configPath || !w.configChanged {
+		t.Fatalf("config change should be reported and trigger a reload: changed=%v reload=%t err=%v", changed, w.configChanged, err)
+	}
+}
+
+func TestDebouncer_WaitsForQuietPeriod(t *testing.T) {
+	pending := &debouncer{delay: 80 * time.Millisecond}
+	defer pending.stop()
+
+	pending.trigger()
+	time.Sleep(40 * time.Millisecond)
+	fired := pending.trigger()
+
+	select {
+	case <-fired:
+		t.Fatalf("a change during the quiet period should restart it")
+	case <-time.After(50 * time.Millisecond):
+	}
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatalf("debouncer should fire once changes settle")
+	}
+	select {
+	case <-fired:
+		t.Fatalf("a burst of changes should fire only once")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,391 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/gopkg-dev/swagger-ts-gen/internal/generator"
+	"github.com/gopkg-dev/swagger-ts-gen/internal/lint"
+	"github.com/gopkg-dev/swagger-ts-gen/internal/loader"
+)
+
+// Version is the only config schema version understood by this build.
+const Version = 1
+
+// FileNames lists the config file names discovered automatically, in lookup order.
+var FileNames = []string{
+	"swagger-ts.config.yaml",
+	"swagger-ts.config.yml",
+	"swagger-ts.config.json",
+}
+
+// Config mirrors the CLI flags and generator options in a versioned project file.
+// Pointer fields distinguish "not set" from an explicit false.
+type Config struct {
+	Version int    `json:"version"`
+	Input   string `json:"input,omitempty"`
+	// Inputs merges several specs into one output tree; mutually exclusive with Input.
+	Inputs                 []InputConfig `json:"inputs,omitempty"`
+	Output                 string        `json:"output,omitempty"`
+	Verbose                *bool         `json:"verbose,omitempty"`
+	GoSource               string        `json:"goSource,omitempty"`
+	GoSourceInclude        []string      `json:"goSourceInclude,omitempty"`
+	RequiredByOmitEmpty    *bool         `json:"requiredByOmitEmpty,omitempty"`
+	CleanOutput            *bool         `json:"cleanOutput,omitempty"`
+	DedupeCrossGroupModels *bool         `json:"dedupeCrossGroupModels,omitempty"`
+	Strict                 *bool         `json:"strict,omitempty"`
+	Manifest               *bool         `json:"manifest,omitempty"`
+	EnumStyle              string        `json:"enumStyle,omitempty"`
+	TypeGuards             *bool         `json:"typeGuards,omitempty"`
+
+	Envelope   *EnvelopeConfig   `json:"envelope,omitempty"`
+	Pagination *PaginationConfig `json:"pagination,omitempty"`
+	Grouping   *GroupingConfig   `json:"grouping,omitempty"`
+	Fetch      *FetchConfig      `json:"fetch,omitempty"`
+	Lint       *LintConfig       `json:"lint,omitempty"`
+	// Scalars maps string and number formats to TypeScript types, e.g. int64: string.
+	Scalars map[string]string `json:"scalars,omitempty"`
+	// TypeOverrides replaces the type of schemas keyed by component name or JSON pointer.
+	TypeOverrides map[string]TypeOverrideConfig `json:"typeOverrides,omitempty"`
+
+	// Path is the file the config was loaded from; empty when parsed from memory.
+	Path string `json:"-"`
+}
+
+// EnvelopeConfig describes the backend response wrapper; omitted fields keep the go-fiber-admin defaults.
+type EnvelopeConfig struct {
+	// Mode is "wrapped" (default) or "unwrapped" when responses carry no envelope at all.
+	Mode           string         `json:"mode,omitempty"`
+	DataField      string         `json:"dataField,omitempty"`
+	Success        *SuccessConfig `json:"success,omitempty"`
+	MessageField   string         `json:"messageField,omitempty"`
+	ReasonField    string         `json:"reasonField,omitempty"`
+	UnwrappedPaths []string       `json:"unwrappedPaths,omitempty"`
+}
+
+// SuccessConfig is the success predicate: Field === Equals, or a truthiness check when Equals is omitted.
+type SuccessConfig struct {
+	Field  string `json:"field,omitempty"`
+	Equals any    `json:"equals,omitempty"`
+}
+
+// PaginationConfig selects pagination profiles for the whole spec (default) or per path prefix (rules).
+type PaginationConfig struct {
+	Default  string                    `json:"default,omitempty"`
+	Profiles []PaginationProfileConfig `json:"profiles,omitempty"`
+	Rules    []PaginationRuleConfig    `json:"rules,omitempty"`
+}
+
+// PaginationProfileConfig defines a new profile or overrides fields of a built-in one with the same name.
+type PaginationProfileConfig struct {
+	Name            string   `json:"name"`
+	Kind            string   `json:"kind,omitempty"`
+	Params          []string `json:"params,omitempty"`
+	CursorParam     string   `json:"cursorParam,omitempty"`
+	ListField       string   `json:"listField,omitempty"`
+	TotalField      string   `json:"totalField,omitempty"`
+	NextCursorField string   `json:"nextCursorField,omitempty"`
+	ParamType       string   `json:"paramType,omitempty"`
+	ResultType      string   `json:"resultType,omitempty"`
+}
+
+type PaginationRuleConfig struct {
+	PathPrefix string `json:"pathPrefix"`
+	Profile    string `json:"profile"`
+}
+
+// InputConfig is one spec of a multi-spec run.
+type InputConfig struct {
+	Input string `json:"input"`
+	// Namespace prefixes the groups of this spec, e.g. "iam" turns "users" into "iamUsers".
+	Namespace string `json:"namespace,omitempty"`
+	// BaseURL is prepended to the request URLs of this spec.
+	BaseURL string `json:"baseURL,omitempty"`
+}
+
+// GroupingConfig selects the grouping strategy; rules map a path prefix and/or tag to a group directory.
+type GroupingConfig struct {
+	Strategy string               `json:"strategy,omitempty"`
+	Rules    []GroupingRuleConfig `json:"rules,omitempty"`
+}
+
+type GroupingRuleConfig struct {
+	PathPrefix string `json:"pathPrefix,omitempty"`
+	Tag        string `json:"tag,omitempty"`
+	Group      string `json:"group"`
+}
+
+// FetchConfig configures how a remote input URL is fetched. Header values, basic auth and the
+// bearer token support $VAR / ${VAR} expansion so secrets can stay in the environment.
+type FetchConfig struct {
+	Headers     map[string]string `json:"headers,omitempty"`
+	BasicAuth   *BasicAuthConfig  `json:"basicAuth,omitempty"`
+	BearerToken string            `json:"bearerToken,omitempty"`
+	CAFile      string            `json:"caFile,omitempty"`
+	Insecure    *bool             `json:"insecure,omitempty"`
+	Proxy       string            `json:"proxy,omitempty"`
+	// Timeout is a Go duration such as "30s".
+	Timeout string `json:"timeout,omitempty"`
+	// MaxBodySize is the response size limit in bytes.
+	MaxBodySize int64 `json:"maxBodySize,omitempty"`
+}
+
+// LintConfig tunes `swagger-ts lint`: the failure threshold and per-rule severities.
+type LintConfig struct {
+	// FailOn is the lowest severity that fails the run: info, warning, error (default) or none.
+	FailOn string `json:"failOn,omitempty"`
+	// Rules maps a rule ID to off, info, warning or error.
+	Rules map[string]RuleSeverity `json:"rules,omitempty"`
+}
+
+// RuleSeverity is a lint severity. YAML 1.1 reads an unquoted off as false, so false means off.
+type RuleSeverity string
+
+func (r *RuleSeverity) UnmarshalJSON(data []byte) error {
+	if string(data) == "false" {
+		*r = RuleSeverity(lint.SeverityOff)
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*r = RuleSeverity(value)
+	return nil
+}
+
+// TypeOverrideConfig is either a type expression or an object that also names its import module.
+type TypeOverrideConfig struct {
+	Type   string `json:"type"`
+	Import string `json:"import,omitempty"`
+}
+
+func (t *TypeOverrideConfig) UnmarshalJSON(data []byte) error {
+	var tsType string
+	if err := json.Unmarshal(data, &tsType); err == nil {
+		*t = TypeOverrideConfig{Type: tsType}
+		return nil
+	}
+	type plain TypeOverrideConfig
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+type BasicAuthConfig struct {
+	Username string `json:"username"`
+	Password string `json:"password,omitempty"`
+}
+
+// Discover walks from startDir up to the filesystem root and returns the first config file found.
+// It returns an empty path without error when no config file exists.
+func Discover(startDir string) (string, error) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return "", fmt.Errorf("resolve config search dir failed: %w", err)
+	}
+	for {
+		for _, name := range FileNames {
+			candidate := filepath.Join(dir, name)
+			info, statErr := os.Stat(candidate)
+			if statErr == nil && !info.IsDir() {
+				return candidate, nil
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Load reads a config file and resolves relative paths against the file's directory.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config failed: %w", err)
+	}
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("config %s: %w", path, err)
+	}
+	cfg.Path = path
+	cfg.resolvePaths(filepath.Dir(path))
+	return cfg, nil
+}
+
+// Parse decodes a YAML or JSON config document and rejects unknown keys and unsupported versions.
+func Parse(data []byte) (*Config, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse config failed: %w", err)
+	}
+
+	var raw any
+	if err := json.Unmarshal(jsonData, &raw); err != nil {
+		return nil, fmt.Errorf("parse config failed: %w", err)
+	}
+	if raw == nil {
+		return nil, errors.New("config is empty")
+	}
+	if err := checkKeys(raw, reflect.TypeOf(Config{}), ""); err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	if err := json.Unmarshal(jsonData, cfg); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return nil, fmt.Errorf("invalid value for key %q: expected %s", typeErr.Field, typeErr.Type)
+		}
+		return nil, fmt.Errorf("decode config failed: %w", err)
+	}
+
+	if cfg.Version == 0 {
+		return nil, fmt.Errorf("missing key \"version\": set version: %d", Version)
+	}
+	if cfg.Version != Version {
+		return nil, fmt.Errorf("invalid value for key \"version\": unsupported version %d (supported: %d)", cfg.Version, Version)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// ApplyTo copies settings that only exist in the config file (no CLI flag) into generator options.
+func (c *Config) ApplyTo(opts *generator.Options) {
+	if c == nil || opts == nil {
+		return
+	}
+	if c.Envelope != nil {
+		opts.Envelope = c.Envelope.toGenerator()
+	}
+	if c.Pagination != nil {
+		opts.Pagination = c.Pagination.toGenerator()
+	}
+	if len(c.Scalars) > 0 {
+		opts.Scalars = c.Scalars
+	}
+	if len(c.TypeOverrides) > 0 {
+		opts.TypeOverrides = make(map[string]generator.TypeOverride, len(c.TypeOverrides))
+		for key, override := range c.TypeOverrides {
+			opts.TypeOverrides[key] = generator.TypeOverride{Type: override.Type, Import: override.Import}
+		}
+	}
+	if c.Grouping != nil {
+		// The strategy has a CLI flag (--group-by) and is merged by the caller.
+		for _, rule := range c.Grouping.Rules {
+			opts.Grouping.Rules = append(opts.Grouping.Rules, generator.GroupRule{PathPrefix: rule.PathPrefix, Tag: rule.Tag, Group: rule.Group})
+		}
+	}
+}
+
+// LoaderOptions returns the fetch settings from the config file; the zero value when none are set.
+func (c *Config) LoaderOptions() loader.Options {
+	if c == nil || c.Fetch == nil {
+		return loader.Options{}
+	}
+	fetch := c.Fetch
+	opts := loader.Options{
+		Headers:     fetch.Headers,
+		BearerToken: fetch.BearerToken,
+		CAFile:      fetch.CAFile,
+		Proxy:       fetch.Proxy,
+		MaxBodySize: fetch.MaxBodySize,
+	}
+	if fetch.BasicAuth != nil {
+		opts.BasicAuth = &loader.BasicAuth{Username: fetch.BasicAuth.Username, Password: fetch.BasicAuth.Password}
+	}
+	if fetch.Insecure != nil {
+		opts.Insecure = *fetch.Insecure
+	}
+	// Timeout was validated in Parse.
+	opts.Timeout, _ = time.ParseDuration(fetch.Timeout)
+	return opts
+}
+
+// LintSeverities returns the per-rule severity overrides from the config file.
+func (c *Config) LintSeverities() map[string]lint.Severity {
+	if c == nil || c.Lint == nil || len(c.Lint.Rules) == 0 {
+		return nil
+	}
+	severities := make(map[string]lint.Severity, len(c.Lint.Rules))
+	for id, value := range c.Lint.Rules {
+		// Values were validated in Parse.
+		severities[id], _ = lint.ParseSeverity(string(value))
+	}
+	return severities
+}
+
+func (p *PaginationConfig) toGenerator() generator.Pagination {
+	pagination := generator.Pagination{Default: p.Default}
+	for _, profile := range p.Profiles {
+		pagination.Profiles = append(pagination.Profiles, generator.PaginationProfile{
+			Name:            profile.Name,
+			Kind:            profile.Kind,
+			Params:          profile.Params,
+			CursorParam:     profile.CursorParam,
+			ListField:       profile.ListField,
+			TotalField:      profile.TotalField,
+			NextCursorField: profile.NextCursorField,
+			ParamType:       profile.ParamType,
+			ResultType:      profile.ResultType,
+		})
+	}
+	for _, rule := range p.Rules {
+		pagination.Rules = append(pagination.Rules, generator.PaginationRule{PathPrefix: rule.PathPrefix, Profile: rule.Profile})
+	}
+	return pagination
+}
+
+func (e *EnvelopeConfig) toGenerator() generator.Envelope {
+	envelope := generator.Envelope{
+		Unwrapped:      e.Mode == "unwrapped",
+		DataPath:       e.DataField,
+		MessageField:   e.MessageField,
+		ReasonField:    e.ReasonField,
+		UnwrappedPaths: e.UnwrappedPaths,
+	}
+	if e.Success != nil {
+		envelope.SuccessField = e.Success.Field
+		envelope.SuccessValue = e.Success.Equals
+	}
+	return envelope
+}
+
+func (c *Config) resolvePaths(baseDir string) {
+	c.Input = resolveInputPath(baseDir, c.Input)
+	for idx := range c.Inputs {
+		c.Inputs[idx].Input = resolveInputPath(baseDir, c.Inputs[idx].Input)
+	}
+	c.Output = resolveLocalPath(baseDir, c.Output)
+	c.GoSource = resolveLocalPath(baseDir, c.GoSource)
+	if c.Fetch != nil {
+		c.Fetch.CAFile = resolveLocalPath(baseDir, c.Fetch.CAFile)
+	}
+}
+
+func resolveInputPath(baseDir string, input string) string {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == loader.StdinInput || strings.HasPrefix(trimmed, "http://") || strings.HasPrefix(trimmed, "https://") {
+		return trimmed
+	}
+	return resolveLocalPath(baseDir, trimmed)
+}
+
+func resolveLocalPath(baseDir string, path string) string {
+	trimmed := strings.TrimSpace(path)
+	if trimmed == "" || filepath.IsAbs(trimmed) {
+		return trimmed
+	}
+	return filepath.Join(baseDir, trimmed)
+}
@@ -0,0 +1,353 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gopkg-dev/swagger-ts-gen/internal/generator"
+	"github.com/gopkg-dev/swagger-ts-gen/internal/lint"
+)
+
+func TestParse_DecodesYAML(t *testing.T) {
+	cfg, err := Parse([]byte(`
+version: 1
+input: ./doc.json
+output: ./src/api
+goSourceInclude: [schema, dto]
+requiredByOmitEmpty: true
+cleanOutput: false
+`))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if cfg.Input != "./doc.json" || cfg.Output != "./src/api" {
+		t.Fatalf("unexpected paths: %+v", cfg)
+	}
+	if cfg.RequiredByOmitEmpty == nil || !*cfg.RequiredByOmitEmpty {
+		t.Fatalf("requiredByOmitEmpty should be true: %+v", cfg)
+	}
+	if cfg.CleanOutput == nil || *cfg.CleanOutput {
+		t.Fatalf("cleanOutput should be explicitly false: %+v", cfg)
+	}
+	if cfg.DedupeCrossGroupModels != nil {
+		t.Fatalf("dedupeCrossGroupModels should stay unset: %+v", cfg)
+	}
+	if strings.Join(cfg.GoSourceInclude, ",") != "schema,dto" {
+		t.Fatalf("unexpected goSourceInclude: %v", cfg.GoSourceInclude)
+	}
+}
+
+func TestParse_ReportsUnknownKey(t *testing.T) {
+	_, err := Parse([]byte("version: 1\ngoSources: ./backend\n"))
+	if err == nil {
+		t.Fatal("expected unknown key error")
+	}
+	if !strings.Contains(err.Error(), `unknown key "goSources"`) {
+		t.Fatalf("error should name the bad key: %v", err)
+	}
+}
+
+func TestParse_ReportsFirstUnknownKeyInOrder(t *testing.T) {
+	data := []byte("version: 1\nscalars:\n  x: string\ntypeOverrides:\n  Zed: {type: string, zz: 1}\n  Abc: {type: string, aa: 1}\n")
+	for i := 0; i < 20; i++ {
+		_, err := Parse(data)
+		if err == nil || !strings.Contains(err.Error(), `unknown key "typeOverrides.Abc.aa"`) {
+			t.Fatalf("expected the first unknown key in sorted order, got %v", err)
+		}
+	}
+}
+
+func TestParse_ReportsInvalidValueKey(t *testing.T) {
+	_, err := Parse([]byte(`{"version": 1, "cleanOutput": "yes"}`))
+	if err == nil {
+		t.Fatal("expected type error")
+	}
+	if !strings.Contains(err.Error(), `"cleanOutput"`) {
+		t.Fatalf("error should name the bad key: %v", err)
+	}
+}
+
+func TestParse_RejectsMissingAndUnsupportedVersion(t *testing.T) {
+	if _, err := Parse([]byte("input: ./doc.json\n")); err == nil || !strings.Contains(err.Error(), `"version"`) {
+		t.Fatalf("expected missing version error, got %v", err)
+	}
+	if _, err := Parse([]byte("version: 2\n")); err == nil || !strings.Contains(err.Error(), "unsupported version 2") {
+		t.Fatalf("expected unsupported version error, got %v", err)
+	}
+}
+
+func TestDiscoverAndLoad_ResolvesRelativePathsFromConfigDir(t *testing.T) {
+	rootDir := t.TempDir()
+	nestedDir := filepath.Join(rootDir, "web", "src")
+	if err := os.MkdirAll(nestedDir, 0o755); err != nil {
+		t.Fatalf("create nested dir failed: %v", err)
+	}
+	configPath := filepath.Join(rootDir, "swagger-ts.config.yaml")
+	content := "version: 1\ninput: ./doc.json\noutput: web/api\n"
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+
+	discovered, err := Discover(nestedDir)
+	if err != nil {
+		t.Fatalf("Discover returned error: %v", err)
+	}
+	if discovered != configPath {
+		t.Fatalf("unexpected discovered path: got %s want %s", discovered, configPath)
+	}
+
+	cfg, err := Load(discovered)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.Input != filepath.Join(rootDir, "doc.json") {
+		t.Fatalf("input should resolve against config dir: %s", cfg.Input)
+	}
+	if cfg.Output != filepath.Join(rootDir, "web", "api") {
+		t.Fatalf("output should resolve against config dir: %s", cfg.Output)
+	}
+}
+
+func TestParse_EnvelopeAppliesToGeneratorOptions(t *testing.T) {
+	cfg, err := Parse([]byte(`
+version: 1
+envelope:
+  dataField: result
+  success:
+    field: code
+    equals: 0
+  messageField: msg
+  unwrappedPaths: [/health]
+`))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	opts := generator.Options{}
+	cfg.ApplyTo(&opts)
+	if opts.Envelope.DataPath != "result" || opts.Envelope.SuccessField != "code" || opts.Envelope.MessageField != "msg" {
+		t.Fatalf("unexpected envelope: %+v", opts.Envelope)
+	}
+	if opts.Envelope.SuccessValue != float64(0) {
+		t.Fatalf("unexpected success value: %#v", opts.Envelope.SuccessValue)
+	}
+}
+
+func TestParse_ReportsInvalidEnvelopeMode(t *testing.T) {
+	_, err := Parse([]byte("version: 1\nenvelope:\n  mode: raw\n"))
+	if err == nil || !strings.Contains(err.Error(), `"envelope.mode"`) {
+		t.Fatalf("expected envelope.mode error, got %v", err)
+	}
+	_, err = Parse([]byte("version: 1\nenvelope:\n  dataFeild: result\n"))
+	if err == nil || !strings.Contains(err.Error(), `unknown key "envelope.dataFeild"`) {
+		t.Fatalf("expected nested unknown key error, got %v", err)
+	}
+}
+
+func TestParse_ReportsInvalidEnumStyle(t *testing.T) {
+	cfg, err := Parse([]byte("version: 1\nenumStyle: enum\n"))
+	if err != nil || cfg.EnumStyle != "enum" {
+		t.Fatalf("expected enum style, got %+v, %v", cfg, err)
+	}
+	_, err = Parse([]byte("version: 1\nenumStyle: object\n"))
+	if err == nil || !strings.Contains(err.Error(), `"enumStyle"`) {
+		t.Fatalf("expected enumStyle error, got %v", err)
+	}
+}
+
+func TestParse_ScalarsApplyToGeneratorOptions(t *testing.T) {
+	cfg, err := Parse([]byte("version: 1\nscalars:\n  int64: string\n  date-time: string\n"))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	var opts generator.Options
+	cfg.ApplyTo(&opts)
+	if opts.Scalars["int64"] != "string" || opts.Scalars["date-time"] != "string" {
+		t.Fatalf("unexpected scalars: %v", opts.Scalars)
+	}
+	_, err = Parse([]byte("version: 1\nscalars:\n  decimal: ''\n"))
+	if err == nil || !strings.Contains(err.Error(), `"scalars.decimal"`) {
+		t.Fatalf("expected scalars.decimal error, got %v", err)
+	}
+}
+
+func TestParse_TypeOverridesAcceptStringOrObject(t *testing.T) {
+	cfg, err := Parse([]byte(`
+version: 1
+typeOverrides:
+  Money: string
+  /components/schemas/Order/properties/meta:
+    type: JsonObject
+    import: "@/types/json"
+`))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	var opts generator.Options
+	cfg.ApplyTo(&opts)
+	if opts.TypeOverrides["Money"] != (generator.TypeOverride{Type: "string"}) {
+		t.Fatalf("unexpected Money override: %+v", opts.TypeOverrides["Money"])
+	}
+	meta := opts.TypeOverrides["/components/schemas/Order/properties/meta"]
+	if meta != (generator.TypeOverride{Type: "JsonObject", Import: "@/types/json"}) {
+		t.Fatalf("unexpected meta override: %+v", meta)
+	}
+
+	_, err = Parse([]byte("version: 1\ntypeOverrides:\n  Money:\n    import: '@/types/money'\n"))
+	if err == nil || !strings.Contains(err.Error(), `"typeOverrides.Money"`) {
+		t.Fatalf("expected typeOverrides.Money error, got %v", err)
+	}
+	_, err = Parse([]byte("version: 1\ntypeOverrides:\n  Money:\n    kind: string\n"))
+	if err == nil || !strings.Contains(err.Error(), `"typeOverrides.Money.kind"`) {
+		t.Fatalf("expected unknown key error, got %v", err)
+	}
+}
+
+func TestParse_PaginationRulesReferenceKnownProfiles(t *testing.T) {
+	cfg, err := Parse([]byte(`
+version: 1
+pagination:
+  default: fiberx
+  profiles:
+    - name: legacy
+      params: [pageNo, pageSize]
+      listField: rows
+      totalField: total
+      paramType: LegacyPageParam
+      resultType: LegacyPageResult
+  rules:
+    - pathPrefix: /api/v2
+      profile: cursor
+    - pathPrefix: /legacy
+      profile: legacy
+`))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	opts := generator.Options{}
+	cfg.ApplyTo(&opts)
+	if len(opts.Pagination.Profiles) != 1 || len(opts.Pagination.Rules) != 2 {
+		t.Fatalf("unexpected pagination options: %+v", opts.Pagination)
+	}
+
+	_, err = Parse([]byte("version: 1\npagination:\n  rules:\n    - pathPrefix: /x\n      profile: missing\n"))
+	if err == nil || !strings.Contains(err.Error(), `"pagination.rules[0].profile"`) {
+		t.Fatalf("expected unknown profile error, got %v", err)
+	}
+}
+
+func TestParse_GroupingRulesApplyWithoutStrategy(t *testing.T) {
+	cfg, err := Parse([]byte(`
+version: 1
+grouping:
+  strategy: tag
+  rules:
+    - tag: 角色管理
+      group: roles
+`))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	opts := generator.Options{Grouping: generator.Grouping{Strategy: generator.GroupByPath}}
+	cfg.ApplyTo(&opts)
+	if opts.Grouping.Strategy != generator.GroupByPath || len(opts.Grouping.Rules) != 1 || opts.Grouping.Rules[0].Group != "roles" {
+		t.Fatalf("unexpected grouping options: %+v", opts.Grouping)
+	}
+
+	_, err = Parse([]byte("version: 1\ngrouping:\n  strategy: folder\n"))
+	if err == nil || !strings.Contains(err.Error(), `"grouping.strategy"`) {
+		t.Fatalf("expected invalid strategy error, got %v", err)
+	}
+	_, err = Parse([]byte("version: 1\ngrouping:\n  rules:\n    - group: misc\n"))
+	if err == nil || !strings.Contains(err.Error(), `"grouping.rules[0].pathPrefix"`) {
+		t.Fatalf("expected missing matcher error, got %v", err)
+	}
+}
+
+func TestParse_FetchMapsToLoaderOptions(t *testing.T) {
+	cfg, err := Parse([]byte(`
+version: 1
+fetch:
+  headers:
+    X-Tenant: acme
+  bearerToken: ${SPEC_TOKEN}
+  insecure: true
+  timeout: 45s
+  maxBodySize: 1048576
+`))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	opts := cfg.LoaderOptions()
+	if opts.Headers["X-Tenant"] != "acme" || opts.BearerToken != "${SPEC_TOKEN}" || !opts.Insecure {
+		t.Fatalf("unexpected loader options: %+v", opts)
+	}
+	if opts.Timeout != 45*time.Second || opts.MaxBodySize != 1048576 {
+		t.Fatalf("unexpected limits: timeout=%s maxBodySize=%d", opts.Timeout, opts.MaxBodySize)
+	}
+
+	_, err = Parse([]byte("version: 1\nfetch:\n  timeout: soon\n"))
+	if err == nil || !strings.Contains(err.Error(), `"fetch.timeout"`) {
+		t.Fatalf("expected invalid timeout error, got %v", err)
+	}
+	_, err = Parse([]byte("version: 1\nfetch:\n  bearerToken: t\n  basicAuth:\n    username: bob\n"))
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Fatalf("expected conflicting credentials error, got %v", err)
+	}
+}
+
+func TestParse_InputsListIsExclusiveWithInput(t *testing.T) {
+	cfg, err := Parse([]byte(`
+version: 1
+inputs:
+  - input: ./sys.json
+  - input: https://iam.example.com/swagger/doc.json
+    namespace: iam
+    baseURL: /iam
+`))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if len(cfg.Inputs) != 2 || cfg.Inputs[1].Namespace != "iam" || cfg.Inputs[1].BaseURL != "/iam" {
+		t.Fatalf("unexpected inputs: %+v", cfg.Inputs)
+	}
+
+	_, err = Parse([]byte("version: 1\ninput: ./doc.json\ninputs:\n  - input: ./sys.json\n"))
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Fatalf("expected exclusive inputs error, got %v", err)
+	}
+	_, err = Parse([]byte("version: 1\ninputs:\n  - namespace: iam\n"))
+	if err == nil || !strings.Contains(err.Error(), `"inputs[0].input"`) {
+		t.Fatalf("expected missing input error, got %v", err)
+	}
+}
+
+func TestParse_LintRulesMapToSeverities(t *testing.T) {
+	cfg, err := Parse([]byte(`
+version: 1
+lint:
+  failOn: warning
+  rules:
+    missing-operation-id: off
+    empty-data-schema: error
+`))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	severities := cfg.LintSeverities()
+	if severities["missing-operation-id"] != lint.SeverityOff || severities["empty-data-schema"] != lint.SeverityError {
+		t.Fatalf("unexpected severities: %+v", severities)
+	}
+
+	_, err = Parse([]byte("version: 1\nlint:\n  rules:\n    no-such-rule: error\n"))
+	if err == nil || !strings.Contains(err.Error(), `"lint.rules.no-such-rule"`) {
+		t.Fatalf("expected unknown rule error, got %v", err)
+	}
+	_, err = Parse([]byte("version: 1\nlint:\n  failOn: fatal\n"))
+	if err == nil || !strings.Contains(err.Error(), `"lint.failOn"`) {
+		t.Fatalf("expected invalid failOn error, got %v", err)
+	}
+}
@@ -0,0 +1,254 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gopkg-dev/swagger-ts-gen/internal/generator"
+	"github.com/gopkg-dev/swagger-ts-gen/internal/lint"
+)
+
+func (c *Config) validate() error {
+	if len(c.Inputs) > 0 && strings.TrimSpace(c.Input) != "" {
+		return errors.New("invalid value for key \"inputs\": input and inputs are mutually exclusive")
+	}
+	for idx, input := range c.Inputs {
+		if strings.TrimSpace(input.Input) == "" {
+			return fmt.Errorf("missing key \"inputs[%d].input\"", idx)
+		}
+	}
+	switch c.EnumStyle {
+	case "", generator.EnumStyleConst, generator.EnumStyleEnum, generator.EnumStyleUnion:
+	default:
+		return fmt.Errorf("invalid value for key \"enumStyle\": %q (expected %s, %s or %s)", c.EnumStyle, generator.EnumStyleConst, generator.EnumStyleEnum, generator.EnumStyleUnion)
+	}
+	if c.Envelope != nil {
+		switch c.Envelope.Mode {
+		case "", "wrapped", "unwrapped":
+		default:
+			return fmt.Errorf("invalid value for key \"envelope.mode\": %q (expected wrapped or unwrapped)", c.Envelope.Mode)
+		}
+		if c.Envelope.Success != nil && strings.TrimSpace(c.Envelope.Success.Field) == "" {
+			return errors.New("missing key \"envelope.success.field\"")
+		}
+	}
+	if c.Pagination != nil {
+		if err := c.Pagination.validate(); err != nil {
+			return err
+		}
+	}
+	if c.Grouping != nil {
+		if err := c.Grouping.validate(); err != nil {
+			return err
+		}
+	}
+	if c.Fetch != nil {
+		if err := c.Fetch.validate(); err != nil {
+			return err
+		}
+	}
+	if c.Lint != nil {
+		if err := c.Lint.validate(); err != nil {
+			return err
+		}
+	}
+	formats := make([]string, 0, len(c.Scalars))
+	for format := range c.Scalars {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	for _, format := range formats {
+		if strings.TrimSpace(c.Scalars[format]) == "" {
+			return fmt.Errorf("invalid value for key %q: expected a TypeScript type", "scalars."+format)
+		}
+	}
+	keys := make([]string, 0, len(c.TypeOverrides))
+	for key := range c.TypeOverrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if strings.TrimSpace(c.TypeOverrides[key].Type) == "" {
+			return fmt.Errorf("invalid value for key %q: expected a TypeScript type", "typeOverrides."+key)
+		}
+	}
+	return nil
+}
+
+func (l *LintConfig) validate() error {
+	if l.FailOn != "" && l.FailOn != "none" {
+		if severity, err := lint.ParseSeverity(l.FailOn); err != nil || severity == lint.SeverityOff {
+			return fmt.Errorf("invalid value for key \"lint.failOn\": %q (expected info, warning, error or none)", l.FailOn)
+		}
+	}
+	ids := make([]string, 0, len(l.Rules))
+	for id := range l.Rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if !lint.IsRule(id) {
+			return fmt.Errorf("unknown key %q", "lint.rules."+id)
+		}
+		if _, err := lint.ParseSeverity(string(l.Rules[id])); err != nil {
+			return fmt.Errorf("invalid value for key %q: %q (expected off, info, warning or error)", "lint.rules."+id, l.Rules[id])
+		}
+	}
+	return nil
+}
+
+func (f *FetchConfig) validate() error {
+	if f.Timeout != "" {
+		timeout, err := time.ParseDuration(f.Timeout)
+		if err != nil || timeout <= 0 {
+			return fmt.Errorf("invalid value for key \"fetch.timeout\": %q (expected a positive duration such as 30s)", f.Timeout)
+		}
+	}
+	if f.MaxBodySize < 0 {
+		return errors.New("invalid value for key \"fetch.maxBodySize\": must not be negative")
+	}
+	if f.BasicAuth != nil && strings.TrimSpace(f.BasicAuth.Username) == "" {
+		return errors.New("missing key \"fetch.basicAuth.username\"")
+	}
+	if f.BasicAuth != nil && f.BearerToken != "" {
+		return errors.New("invalid value for key \"fetch.bearerToken\": basicAuth and bearerToken are mutually exclusive")
+	}
+	return nil
+}
+
+func (g *GroupingConfig) validate() error {
+	switch g.Strategy {
+	case "", generator.GroupByPath, generator.GroupByTag:
+	default:
+		return fmt.Errorf("invalid value for key \"grouping.strategy\": %q (expected %s or %s)", g.Strategy, generator.GroupByPath, generator.GroupByTag)
+	}
+	for idx, rule := range g.Rules {
+		if strings.TrimSpace(rule.Group) == "" {
+			return fmt.Errorf("missing key \"grouping.rules[%d].group\"", idx)
+		}
+		if rule.PathPrefix == "" && rule.Tag == "" {
+			return fmt.Errorf("missing key \"grouping.rules[%d].pathPrefix\" or \"grouping.rules[%d].tag\"", idx, idx)
+		}
+	}
+	return nil
+}
+
+func (p *PaginationConfig) validate() error {
+	known := map[string]struct{}{}
+	for _, profile := range generator.BuiltinPaginationProfiles() {
+		known[profile.Name] = struct{}{}
+	}
+	for idx, profile := range p.Profiles {
+		if strings.TrimSpace(profile.Name) == "" {
+			return fmt.Errorf("missing key \"pagination.profiles[%d].name\"", idx)
+		}
+		switch profile.Kind {
+		case "", generator.PaginationKindPage, generator.PaginationKindCursor:
+		default:
+			return fmt.Errorf("invalid value for key \"pagination.profiles[%d].kind\": %q (expected page or cursor)", idx, profile.Kind)
+		}
+		known[profile.Name] = struct{}{}
+	}
+	if p.Default != "" {
+		if _, ok := known[p.Default]; !ok {
+			return fmt.Errorf("invalid value for key \"pagination.default\": unknown profile %q", p.Default)
+		}
+	}
+	for idx, rule := range p.Rules {
+		if strings.TrimSpace(rule.PathPrefix) == "" {
+			return fmt.Errorf("missing key \"pagination.rules[%d].pathPrefix\"", idx)
+		}
+		if _, ok := known[rule.Profile]; !ok {
+			return fmt.Errorf("invalid value for key \"pagination.rules[%d].profile\": unknown profile %q", idx, rule.Profile)
+		}
+	}
+	return nil
+}
+
+// checkKeys walks decoded config data against the Go struct layout so errors name the exact offending key path.
+func checkKeys(value any, typ reflect.Type, path string) error {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		fields := jsonFieldsOf(typ)
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			keyPath := joinKeyPath(path, key)
+			field, exists := fields[key]
+			if !exists {
+				return fmt.Errorf("unknown key %q", keyPath)
+			}
+			if err := checkKeys(object[key], field.Type, keyPath); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]any)
+		if !ok {
+			return nil
+		}
+		for idx, item := range items {
+			if err := checkKeys(item, typ.Elem(), fmt.Sprintf("%s[%d]", path, idx)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if err := checkKeys(object[key], typ.Elem(), joinKeyPath(path, key)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func jsonFieldsOf(typ reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+func joinKeyPath(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,414 @@
+// Package diff compares the TypeScript API surfaces generated from two specs and classifies
+// every change as breaking or not for the frontend code that calls the generated client.
+package diff
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gopkg-dev/swagger-ts-gen/internal/surface"
+)
+
+// Change kinds.
+const (
+	FunctionAdded      = "function-added"
+	FunctionRemoved    = "function-removed"
+	FunctionRenamed    = "function-renamed"
+	FunctionMoved      = "function-moved"
+	ArgumentAdded      = "argument-added"
+	ArgumentRemoved    = "argument-removed"
+	ReturnTypeChanged  = "return-type-changed"
+	TypeAdded          = "type-added"
+	TypeRemoved        = "type-removed"
+	TypeKindChanged    = "type-kind-changed"
+	TypeExtendsChanged = "type-extends-changed"
+	FieldAdded         = "field-added"
+	FieldRemoved       = "field-removed"
+	FieldRequired      = "field-required"
+	FieldOptional      = "field-optional"
+	FieldTypeChanged   = "field-type-changed"
+	EnumValueAdded     = "enum-value-added"
+	EnumValueRemoved   = "enum-value-removed"
+)
+
+// Change is one difference between two surfaces. Subject names the operation (GET /path) or
+// the type member (group.Type.field) that changed.
+type Change struct {
+	Breaking bool   `json:"breaking"`
+	Kind     string `json:"kind"`
+	Subject  string `json:"subject"`
+	Message  string `json:"message"`
+}
+
+// Breaking counts the breaking changes.
+func Breaking(changes []Change) int {
+	count := 0
+	for _, change := range changes {
+		if change.Breaking {
+			count++
+		}
+	}
+	return count
+}
+
+// Compare lists the changes from old to new: operations first (in path order), then types
+// (in group and name order). Breaking changes are those that can fail type checking or
+// change runtime behaviour of existing callers.
+func Compare(before *surface.Surface, after *surface.Surface) []Change {
+	c := &comparer{
+		oldTypes: indexTypes(before),
+		newTypes: indexTypes(after),
+	}
+	c.oldUsage = usageOf(before, c.oldTypes)
+	c.newUsage = usageOf(after, c.newTypes)
+
+	c.compareFunctions(before, after)
+	c.compareTypes(before, after)
+	return c.changes
+}
+
+type comparer struct {
+	oldTypes map[string]surface.Type
+	newTypes map[string]surface.Type
+	oldUsage map[string]usage
+	newUsage map[string]usage
+	changes  []Change
+}
+
+func (c *comparer) add(breaking bool, kind string, subject string, format string, args ...any) {
+	c.changes = append(c.changes, Change{Breaking: breaking, Kind: kind, Subject: subject, Message: fmt.Sprintf(format, args...)})
+}
+
+func operationKey(function surface.Function) string {
+	return function.Method + " " + function.Path
+}
+
+func typeKey(group string, name string) string {
+	return group + "." + name
+}
+
+func (c *comparer) compareFunctions(oldSurface *surface.Surface, newSurface *surface.Surface) {
+	oldFunctions := map[string]surface.Function{}
+	for _, function := range oldSurface.Functions {
+		oldFunctions[operationKey(function)] = function
+	}
+	newFunctions := map[string]surface.Function{}
+	for _, function := range newSurface.Functions {
+		newFunctions[operationKey(function)] = function
+	}
+
+	for _, key := range unionKeys(oldFunctions, newFunctions, operationLess) {
+		before, hadBefore := oldFunctions[key]
+		after, hasAfter := newFunctions[key]
+		switch {
+		case !hasAfter:
+			c.add(true, FunctionRemoved, key, "operation removed (%s.%s)", before.Group, before.Name)
+		case !hadBefore:
+			c.add(false, FunctionAdded, key, "operation added (%s.%s)", after.Group, after.Name)
+		default:
+			c.compareFunction(key, before, after)
+		}
+	}
+}
+
+func (c *comparer) compareFunction(key string, before surface.Function, after surface.Function) {
+	if before.Group != after.Group {
+		c.add(true, FunctionMoved, key, "function moved from group %s to %s", before.Group, after.Group)
+	}
+	if before.Name != after.Name {
+		c.add(true, FunctionRenamed, key, "function renamed from %s to %s", before.Name, after.Name)
+	}
+
+	beforeArgs := fieldsByName(before.Args)
+	afterArgs := fieldsByName(after.Args)
+	for _, arg := range before.Args {
+		if _, ok := afterArgs[arg.Name]; !ok {
+			c.add(true, ArgumentRemoved, key, "argument %s removed", arg.Name)
+		}
+	}
+	for _, arg := range after.Args {
+		previous, ok := beforeArgs[arg.Name]
+		switch {
+		case !ok:
+			c.add(!arg.Optional, ArgumentAdded, key, "%s argument %s: %s added", requiredWord(!arg.Optional), arg.Name, arg.Type)
+		case previous.Optional && !arg.Optional:
+			c.add(true, FieldRequired, key, "argument %s is now required", arg.Name)
+		case !previous.Optional && arg.Optional:
+			c.add(false, FieldOptional, key, "argument %s is now optional", arg.Name)
+		}
+		if ok && previous.Type != arg.Type {
+			c.compareExpr(key, "argument "+arg.Name, previous.Type, arg.Type, usage{input: true})
+		}
+	}
+
+	if before.Returns != after.Returns {
+		c.add(true, ReturnTypeChanged, key, "return type changed from %s to %s", before.Returns, after.Returns)
+	}
+}
+
+func (c *comparer) compareTypes(oldSurface *surface.Surface, newSurface *surface.Surface) {
+	survivors := map[string]bool{}
+	newFunctions := map[string]bool{}
+	for _, function := range newSurface.Functions {
+		newFunctions[operationKey(function)] = true
+	}
+	for _, function := range oldSurface.Functions {
+		if newFunctions[operationKey(function)] {
+			for _, name := range referencedTypes(function.Group, functionTypeExprs(function), c.oldTypes) {
+				survivors[name] = true
+			}
+		}
+	}
+	survivors = closure(survivors, c.oldTypes)
+
+	for _, key := range unionKeys(c.oldTypes, c.newTypes, func(a string, b string) bool { return a < b }) {
+		before, hadBefore := c.oldTypes[key]
+		after, hasAfter := c.newTypes[key]
+		switch {
+		case !hasAfter:
+			// Types that only served removed operations are covered by the function-removed change.
+			if survivors[key] {
+				c.add(true, TypeRemoved, key, "type removed")
+			}
+		case !hadBefore:
+			c.add(false, TypeAdded, key, "type added")
+		default:
+			c.compareType(key, before, after, c.oldUsage[key].merge(c.newUsage[key]))
+		}
+	}
+}
+
+func (c *comparer) compareType(key string, before surface.Type, after surface.Type, use usage) {
+	if before.Kind != after.Kind {
+		c.add(true, TypeKindChanged, key, "changed from %s to %s", before.Kind, after.Kind)
+		return
+	}
+	if strings.Join(before.Extends, ", ") != strings.Join(after.Extends, ", ") {
+		c.add(true, TypeExtendsChanged, key, "extends changed from [%s] to [%s]", strings.Join(before.Extends, ", "), strings.Join(after.Extends, ", "))
+	}
+	if before.Kind == surface.Alias {
+		if before.Alias != after.Alias {
+			c.compareExpr(key, "type", before.Alias, after.Alias, use)
+		}
+		return
+	}
+
+	beforeFields := fieldsByName(before.Fields)
+	afterFields := fieldsByName(after.Fields)
+	for _, field := range before.Fields {
+		if _, ok := afterFields[field.Name]; !ok {
+			c.add(true, FieldRemoved, key+"."+field.Name, "field removed")
+		}
+	}
+	for _, field := range after.Fields {
+		subject := key + "." + field.Name
+		previous, ok := beforeFields[field.Name]
+		switch {
+		case !ok:
+			// New required fields must be sent by callers; new response fields are harmless.
+			c.add(!field.Optional && use.input, FieldAdded, subject, "%s field added: %s", requiredWord(!field.Optional), field.Type)
+			continue
+		case previous.Optional && !field.Optional:
+			c.add(use.input, FieldRequired, subject, "field is now required")
+		case !previous.Optional && field.Optional:
+			// Callers reading the field now have to handle undefined.
+			c.add(use.output, FieldOptional, subject, "field is now optional")
+		}
+		if previous.Type != field.Type {
+			c.compareExpr(subject, "type", previous.Type, field.Type, use)
+		}
+	}
+}
+
+// compareExpr compares two type expressions. Literal unions (enums) are compared by value:
+// removing a value is always breaking; adding one breaks exhaustive handling of response
+// values but is safe for request-only types.
+func (c *comparer) compareExpr(subject string, what string, before string, after string, use usage) {
+	beforeValues, beforeEnum := literalUnion(before)
+	afterValues, afterEnum := literalUnion(after)
+	if !beforeEnum || !afterEnum {
+		c.add(true, FieldTypeChanged, subject, "%s changed from %s to %s", what, before, after)
+		return
+	}
+	for _, value := range beforeValues {
+		if !contains(afterValues, value) {
+			c.add(true, EnumValueRemoved, subject, "enum value %s removed", value)
+		}
+	}
+	for _, value := range afterValues {
+		if !contains(beforeValues, value) {
+			c.add(use.output && !use.input, EnumValueAdded, subject, "enum value %s added", value)
+		}
+	}
+}
+
+// usage records whether a type is reachable from function arguments (input) or return types (output).
+type usage struct {
+	input  bool
+	output bool
+}
+
+func (u usage) merge(other usage) usage {
+	merged := usage{input: u.input || other.input, output: u.output || other.output}
+	// Types not reachable from any function are exported on their own; assume both directions.
+	if !merged.input && !merged.output {
+		return usage{input: true, output: true}
+	}
+	return merged
+}
+
+func usageOf(api *surface.Surface, types map[string]surface.Type) map[string]usage {
+	inputs := map[string]bool{}
+	outputs := map[string]bool{}
+	for _, function := range api.Functions {
+		var argTypes []string
+		for _, arg := range function.Args {
+			argTypes = append(argTypes, arg.Type)
+		}
+		for _, name := range referencedTypes(function.Group, argTypes, types) {
+			inputs[name] = true
+		}
+		for _, name := range referencedTypes(function.Group, []string{function.Returns}, types) {
+			outputs[name] = true
+		}
+	}
+
+	result := map[string]usage{}
+	for name := range closure(inputs, types) {
+		use := result[name]
+		use.input = true
+		result[name] = use
+	}
+	for name := range closure(outputs, types) {
+		use := result[name]
+		use.output = true
+		result[name] = use
+	}
+	return result
+}
+
+func functionTypeExprs(function surface.Function) []string {
+	exprs := []string{function.Returns}
+	for _, arg := range function.Args {
+		exprs = append(exprs, arg.Type)
+	}
+	return exprs
+}
+
+var identifierPattern = regexp.MustCompile(`[A-Za-z_$][A-Za-z0-9_$]*`)
+
+// referencedTypes returns the keys of the group types named in the type expressions.
+func referencedTypes(group string, exprs []string, types map[string]surface.Type) []string {
+	var names []string
+	for _, expr := range exprs {
+		for _, identifier := range identifierPattern.FindAllString(stripStringLiterals(expr), -1) {
+			if _, ok := types[typeKey(group, identifier)]; ok {
+				names = append(names, typeKey(group, identifier))
+			}
+		}
+	}
+	return names
+}
+
+// closure adds every type reachable through fields, aliases and extends.
+func closure(seed map[string]bool, types map[string]surface.Type) map[string]bool {
+	reached := map[string]bool{}
+	queue := make([]string, 0, len(seed))
+	for name := range seed {
+		queue = append(queue, name)
+	}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if reached[name] {
+			continue
+		}
+		reached[name] = true
+		described := types[name]
+		exprs := append([]string{described.Alias}, described.Extends...)
+		for _, field := range described.Fields {
+			exprs = append(exprs, field.Type)
+		}
+		queue = append(queue, referencedTypes(described.Group, exprs, types)...)
+	}
+	return reached
+}
+
+func indexTypes(api *surface.Surface) map[string]surface.Type {
+	types := make(map[string]surface.Type, len(api.Types))
+	for _, described := range api.Types {
+		types[typeKey(described.Group, described.Name)] = described
+	}
+	return types
+}
+
+var literalPattern = regexp.MustCompile(`^('(?:[^'\\]|\\.)*'|-?[0-9][0-9.eE+-]*|true|false|null)$`)
+
+// literalUnion splits an expression such as 'a' | 'b' | null into its literals; ok is false
+// when any member is not a literal.
+func literalUnion(expr string) ([]string, bool) {
+	parts := strings.Split(expr, " | ")
+	for idx, part := range parts {
+		parts[idx] = strings.TrimSpace(part)
+		if !literalPattern.MatchString(parts[idx]) {
+			return nil, false
+		}
+	}
+	return parts, true
+}
+
+var stringLiteralPattern = regexp.MustCompile(`'(?:[^'\\]|\\.)*'`)
+
+func stripStringLiterals(expr string) string {
+	return stringLiteralPattern.ReplaceAllString(expr, "''")
+}
+
+func fieldsByName(fields []surface.Field) map[string]surface.Field {
+	byName := make(map[string]surface.Field, len(fields))
+	for _, field := range fields {
+		byName[field.Name] = field
+	}
+	return byName
+}
+
+func requiredWord(required bool) string {
+	if required {
+		return "required"
+	}
+	return "optional"
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// operationLess orders "METHOD /path" keys by path, then method.
+func operationLess(a string, b string) bool {
+	aMethod, aPath, _ := strings.Cut(a, " ")
+	bMethod, bPath, _ := strings.Cut(b, " ")
+	if aPath != bPath {
+		return aPath < bPath
+	}
+	return aMethod < bMethod
+}
+
+func unionKeys[V any](a map[string]V, b map[string]V, less func(string, string) bool) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return less(keys[i], keys[j]) })
+	return keys
+}
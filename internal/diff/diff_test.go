@@ -0,0 +1,89 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/gopkg-dev/swagger-ts-gen/internal/surface"
+)
+
+func TestCompare_ClassifiesChanges(t *testing.T) {
+	before := &surface.Surface{
+		Functions: []surface.Function{
+			{Group: "users", Name: "listUsers", Method: "GET", Path: "/api/v1/users",
+				Args: []surface.Field{{Name: "params", Type: "ListUsersParam", Optional: true}}, Returns: "User[]"},
+			{Group: "users", Name: "deleteUser", Method: "DELETE", Path: "/api/v1/users/{id}",
+				Args: []surface.Field{{Name: "id", Type: "string"}}, Returns: "void"},
+		},
+		Types: []surface.Type{
+			{Group: "users", Name: "ListUsersParam", Kind: surface.Interface, Fields: []surface.Field{
+				{Name: "keyword", Type: "string", Optional: true},
+			}},
+			{Group: "users", Name: "User", Kind: surface.Interface, Fields: []surface.Field{
+				{Name: "age", Type: "number"},
+				{Name: "status", Type: "'active' | 'disabled' | 'archived'"},
+			}},
+		},
+	}
+	after := &surface.Surface{
+		Functions: []surface.Function{
+			{Group: "users", Name: "listUsers", Method: "GET", Path: "/api/v1/users",
+				Args: []surface.Field{{Name: "params", Type: "ListUsersParam", Optional: true}}, Returns: "PageResult<User>"},
+			{Group: "users", Name: "createUser", Method: "POST", Path: "/api/v1/users",
+				Args: []surface.Field{{Name: "data", Type: "CreateUserBody"}}, Returns: "User"},
+		},
+		Types: []surface.Type{
+			{Group: "users", Name: "CreateUserBody", Kind: surface.Interface},
+			{Group: "users", Name: "ListUsersParam", Kind: surface.Interface, Fields: []surface.Field{
+				{Name: "keyword", Type: "string"},
+			}},
+			{Group: "users", Name: "User", Kind: surface.Interface, Fields: []surface.Field{
+				{Name: "age", Type: "string"},
+				{Name: "nickname", Type: "string"},
+				{Name: "status", Type: "'active' | 'disabled' | 'locked'"},
+			}},
+		},
+	}
+
+	want := []Change{
+		{Breaking: true, Kind: ReturnTypeChanged, Subject: "GET /api/v1/users", Message: "return type changed from User[] to PageResult<User>"},
+		{Breaking: false, Kind: FunctionAdded, Subject: "POST /api/v1/users", Message: "operation added (users.createUser)"},
+		{Breaking: true, Kind: FunctionRemoved, Subject: "DELETE /api/v1/users/{id}", Message: "operation removed (users.deleteUser)"},
+		{Breaking: false, Kind: TypeAdded, Subject: "users.CreateUserBody", Message: "type added"},
+		{Breaking: true, Kind: FieldRequired, Subject: "users.ListUsersParam.keyword", Message: "field is now required"},
+		{Breaking: true, Kind: FieldTypeChanged, Subject: "users.User.age", Message: "type changed from number to string"},
+		{Breaking: false, Kind: FieldAdded, Subject: "users.User.nickname", Message: "required field added: string"},
+		{Breaking: true, Kind: EnumValueRemoved, Subject: "users.User.status", Message: "enum value 'archived' removed"},
+		{Breaking: true, Kind: EnumValueAdded, Subject: "users.User.status", Message: "enum value 'locked' added"},
+	}
+	got := Compare(before, after)
+	if len(got) != len(want) {
+		t.Fatalf("unexpected changes:\n%+v", got)
+	}
+	for idx := range want {
+		if got[idx] != want[idx] {
+			t.Fatalf("change %d:\ngot  %+v\nwant %+v", idx, got[idx], want[idx])
+		}
+	}
+	if Breaking(got) != 6 {
+		t.Fatalf("expected 6 breaking changes, got %d", Breaking(got))
+	}
+}
+
+func TestCompare_RequiredFieldAddedToRequestTypeIsBreaking(t *testing.T) {
+	api := func(fields ...surface.Field) *surface.Surface {
+		return &surface.Surface{
+			Functions: []surface.Function{{Group: "users", Name: "createUser", Method: "POST", Path: "/api/v1/users",
+				Args: []surface.Field{{Name: "data", Type: "CreateUserBody"}}, Returns: "void"}},
+			Types: []surface.Type{{Group: "users", Name: "CreateUserBody", Kind: surface.Interface, Fields: fields}},
+		}
+	}
+
+	got := Compare(api(), api(surface.Field{Name: "email", Type: "string"}))
+	if len(got) != 1 || !got[0].Breaking || got[0].Kind != FieldAdded {
+		t.Fatalf("required request field should be breaking: %+v", got)
+	}
+	got = Compare(api(), api(surface.Field{Name: "email", Type: "string", Optional: true}))
+	if len(got) != 1 || got[0].Breaking {
+		t.Fatalf("optional request field should not be breaking: %+v", got)
+	}
+}
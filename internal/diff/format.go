@@ -0,0 +1,99 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Formats lists the output formats accepted by Write.
+var Formats = []string{"text", "json", "markdown"}
+
+// Write prints the changes as text or markdown (breaking changes first) or as JSON.
+func Write(w io.Writer, format string, changes []Change) error {
+	switch format {
+	case "text", "":
+		return writeText(w, changes)
+	case "markdown":
+		return writeMarkdown(w, changes)
+	case "json":
+		if changes == nil {
+			changes = []Change{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(struct {
+			Breaking    int      `json:"breaking"`
+			NonBreaking int      `json:"nonBreaking"`
+			Changes     []Change `json:"changes"`
+		}{Breaking: Breaking(changes), NonBreaking: len(changes) - Breaking(changes), Changes: changes})
+	}
+	return fmt.Errorf("unknown diff format %q (expected %s)", format, strings.Join(Formats, ", "))
+}
+
+func writeText(w io.Writer, changes []Change) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(w, "No API changes")
+		return err
+	}
+	for _, section := range sections(changes) {
+		if _, err := fmt.Fprintf(w, "%s (%d):\n", section.title, len(section.changes)); err != nil {
+			return err
+		}
+		for _, change := range section.changes {
+			if _, err := fmt.Fprintf(w, "  %s: %s\n", change.Subject, change.Message); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeMarkdown(w io.Writer, changes []Change) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(w, "No API changes.")
+		return err
+	}
+	for idx, section := range sections(changes) {
+		if idx > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "### %s\n\n", section.title); err != nil {
+			return err
+		}
+		for _, change := range section.changes {
+			if _, err := fmt.Fprintf(w, "- `%s`: %s\n", change.Subject, change.Message); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type section struct {
+	title   string
+	changes []Change
+}
+
+// sections splits the changes into breaking and non-breaking, omitting empty sections.
+func sections(changes []Change) []section {
+	var result []section
+	for _, candidate := range []struct {
+		title    string
+		breaking bool
+	}{{"Breaking changes", true}, {"Non-breaking changes", false}} {
+		var selected []Change
+		for _, change := range changes {
+			if change.Breaking == candidate.breaking {
+				selected = append(selected, change)
+			}
+		}
+		if len(selected) > 0 {
+			result = append(result, section{title: candidate.title, changes: selected})
+		}
+	}
+	return result
+}
@@ -0,0 +1,189 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+func renderAPIIndex(parts int) string {
+	var b strings.Builder
+	for i := 1; i <= parts; i++ {
+		b.WriteString(fmt.Sprintf("export * from './api_%d';\n", i))
+	}
+	return b.String()
+}
+
+func SplitAndRenderAPI(ops []Operation, modelImports []string) []string {
+	if len(ops) == 0 {
+		return nil
+	}
+	maxLines := 500
+	opStrings := make([]string, 0, len(ops))
+	opLines := make([]int, 0, len(ops))
+
+	for _, op := range ops {
+		content := RenderOperation(op)
+		opStrings = append(opStrings, content)
+		opLines = append(opLines, countLines(content))
+	}
+
+	header := renderAPIHeader(apiValueImports(ops), apiRootImports(ops), apiTypeImports(ops), modelImports)
+	headerLines := countLines(header)
+
+	var files []string
+	var buffer []string
+	lineCount := headerLines
+
+	for idx, content := range opStrings {
+		lines := opLines[idx]
+		if lineCount+lines > maxLines && len(buffer) > 0 {
+			files = append(files, renderAPIFromChunks(buffer, header, headerLines))
+			buffer = nil
+			lineCount = headerLines
+		}
+		buffer = append(buffer, content)
+		lineCount += lines
+	}
+
+	if len(buffer) > 0 {
+		files = append(files, renderAPIFromChunks(buffer, header, headerLines))
+	}
+
+	return files
+}
+
+func renderAPIFromChunks(chunks []string, header string, headerLines int) string {
+	if len(chunks) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(header)
+	if headerLines > 0 && !strings.HasSuffix(header, "\n\n") {
+		b.WriteString("\n")
+	}
+	for idx, chunk := range chunks {
+		if idx > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(chunk)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func countLines(content string) int {
+	if content == "" {
+		return 0
+	}
+	return strings.Count(content, "\n") + 1
+}
+
+func RenderAPIFile(ops []Operation, modelImports []string) string {
+	var b strings.Builder
+	b.WriteString(renderAPIHeader(apiValueImports(ops), apiRootImports(ops), apiTypeImports(ops), modelImports))
+
+	for idx, op := range ops {
+		if idx > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(RenderOperation(op))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func renderAPIHeader(valueImports []string, rootImports []string, typeImports []TypeImport, modelImports []string) string {
+	var b strings.Builder
+	b.WriteString("import request from '@/utils/request';\n")
+	if len(valueImports) > 0 {
+		b.WriteString("import { " + strings.Join(valueImports, ", ") + " } from '@/api';\n")
+	}
+
+	if len(rootImports) > 0 {
+		b.WriteString("import type { " + strings.Join(rootImports, ", ") + " } from '@/api';\n")
+	}
+	b.WriteString(renderTypeImports(typeImports))
+
+	if len(modelImports) > 0 {
+		sort.Strings(modelImports)
+		b.WriteString("import type {\n")
+		lastIndex := len(modelImports) - 1
+		for idx, name := range modelImports {
+			lineSuffix := ",\n"
+			if idx == lastIndex {
+				lineSuffix = "\n"
+			}
+			b.WriteString("  " + name + lineSuffix)
+		}
+		b.WriteString("} from './model';\n")
+	}
+
+	b.WriteString("\n")
+	return b.String()
+}
+
+// apiValueImports lists the runtime helpers from '@/api' called by the rendered operations.
+func apiValueImports(ops []Operation) []string {
+	if len(ops) == 0 {
+		return nil
+	}
+	usesApiError := false
+	usesDownload := false
+	for _, op := range ops {
+		if op.Return.IsDownload {
+			usesDownload = true
+		} else if !op.Envelope.Unwrapped {
+			usesApiError = true
+		}
+		if op.ErrorType != "" {
+			usesApiError = true
+		}
+	}
+
+	var imports []string
+	if usesApiError {
+		imports = append(imports, "ApiError")
+	}
+	imports = append(imports, "toApiError")
+	if usesDownload {
+		imports = append(imports, "toDownloadResult")
+	}
+	return imports
+}
+
+// apiTypeImports lists the types the operation signatures import from x-ts-import modules.
+func apiTypeImports(ops []Operation) []TypeImport {
+	var imports []TypeImport
+	for _, op := range ops {
+		imports = append(imports, op.Imports...)
+	}
+	return imports
+}
+
+// apiRootImports lists the shared types from '@/api' referenced by the rendered operations.
+func apiRootImports(ops []Operation) []string {
+	usesResult := false
+	var pageTypes []string
+	for _, op := range ops {
+		if !op.Envelope.Unwrapped && !op.Return.IsDownload {
+			usesResult = true
+		}
+		for _, info := range op.Errors {
+			if info.TypeName == "ApiResult" {
+				usesResult = true
+			}
+		}
+		if op.Return.UsesPageResult {
+			pageTypes = append(pageTypes, op.Return.PageResultType)
+		}
+		pageTypes = append(pageTypes, rootImportNames(op.Imports)...)
+	}
+
+	var imports []string
+	if usesResult {
+		imports = append(imports, "ApiResult")
+	}
+	return append(imports, uniqueStrings(pageTypes)...)
+}
@@ -0,0 +1,412 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func (g *Generator) buildGroupOperations(source Source, rawOps []RawOperation) ([]Operation, []string, *TypeRegistry, error) {
+	registry := NewTypeRegistry(source.Spec)
+	registry.SetOptionalFieldsByType(g.optionalFieldsByType)
+	registry.enumStyle = g.enumStyle
+	registry.typeGuards = g.typeGuards
+	registry.scalars = g.scalars
+	registry.overrides = g.typeOverrides
+	usedTypes := map[string]struct{}{}
+
+	ops := make([]Operation, 0, len(rawOps))
+	for _, raw := range rawOps {
+		op := Operation{
+			Name:     ensureUniqueOperationName(raw.Name, ops),
+			Summary:  raw.Summary,
+			Method:   raw.Method,
+			Path:     raw.Path,
+			URL:      source.requestPath(raw.Path),
+			Group:    raw.Group,
+			Envelope: g.envelope.forPath(raw.Path),
+		}
+		registry.scope = operationScope{Operation: op.Name, Method: op.Method, Path: op.Path}
+		// Types registered here are rendered into the model later; only signature types are tracked.
+		stopImports := registry.trackImports()
+
+		op.PathParams = buildPathParams(raw.PathParams, registry)
+		for _, param := range op.PathParams {
+			if _, ok := registry.types[param.Type]; ok {
+				usedTypes[param.Type] = struct{}{}
+			}
+		}
+
+		profile := g.pagination.profileFor(raw.Path)
+		var pageQuery *PaginationProfile
+		if profile.matchesQuery(raw.QueryParams) {
+			pageQuery = &profile
+		}
+		if len(raw.QueryParams) > 0 {
+			querySchema := buildQuerySchema(raw.QueryParams, pageQuery)
+			var typeName string
+			queryParamName := buildQueryParamTypeName(op.Name, op.Group)
+			if pageQuery != nil {
+				typeName = registry.RegisterInlineWithExtends(queryParamName, querySchema, "", []string{pageQuery.ParamType})
+			} else {
+				typeName = registry.RegisterInline(queryParamName, querySchema, "")
+			}
+			op.Query = &QueryInfo{TypeName: typeName, Optional: !hasRequiredParams(raw.QueryParams, pageQuery)}
+			usedTypes[typeName] = struct{}{}
+		}
+
+		if len(raw.HeaderParams) > 0 {
+			headerSchema := buildQuerySchema(raw.HeaderParams, nil)
+			typeName := registry.registerInlineAlias(op.Name+"Headers", headerSchema)
+			op.Headers = &HeaderInfo{TypeName: typeName, Optional: !hasRequiredParams(raw.HeaderParams, nil)}
+			usedTypes[typeName] = struct{}{}
+		}
+		op.Cookies = buildPathParams(raw.CookieParams, registry)
+
+		if raw.Body != nil {
+			registry.direction = directionRequest
+			typeName := ""
+			if raw.Body.Schema != nil && raw.Body.Schema.Ref != "" {
+				refName, err := registry.directedRef(raw.Body.Schema.Ref)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				typeName = refName
+			} else {
+				desc := ""
+				if raw.Body.IsFormData {
+					desc = "FormData"
+				}
+				typeName = registry.RegisterInline(op.Name+"Body", raw.Body.Schema, desc)
+			}
+			op.Body = &BodyInfo{TypeName: typeName, Optional: !raw.Body.Required, IsForm: raw.Body.IsFormData}
+			usedTypes[typeName] = struct{}{}
+		}
+		registry.direction = directionResponse
+
+		if raw.Download {
+			op.Return = ReturnInfo{Type: downloadResultType, IsDownload: true}
+		} else {
+			returnInfo, returnTypes := resolveReturnType(op.Name, raw.Response, registry, profile, pageQuery != nil, op.Envelope)
+			op.Return = returnInfo
+			for _, name := range returnTypes {
+				usedTypes[name] = struct{}{}
+			}
+		}
+
+		errorInfos, errorTypes, err := resolveErrorTypes(op.Name, raw.Errors, registry, op.Envelope, raw.Download)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		op.Errors = errorInfos
+		op.SuccessStatus = raw.SuccessStatus
+		if len(errorInfos) > 0 {
+			op.ErrorType = registry.ensureUniqueName(sanitizeTypeName(op.Name) + "Error")
+		}
+		for _, name := range errorTypes {
+			usedTypes[name] = struct{}{}
+		}
+		registry.direction = directionNone
+
+		op.ErrorText = buildErrorText(op.Summary)
+		op.Imports = stopImports()
+
+		if g.logf != nil {
+			g.logf(
+				"operation name=%s method=%s path=%s group=%s page=%t pathParams=%s query=%s queryFields=%s headers=%s cookies=%s body=%s return=%s",
+				op.Name,
+				strings.ToUpper(op.Method),
+				op.Path,
+				op.Group,
+				pageQuery != nil,
+				formatRawParamNames(raw.PathParams, nil),
+				formatQueryLog(op.Query, pageQuery),
+				formatRawParamNames(raw.QueryParams, pageQuery),
+				formatRawParamNames(raw.HeaderParams, nil),
+				formatRawParamNames(raw.CookieParams, nil),
+				formatBodyLog(op.Body),
+				op.Return.Type,
+			)
+		}
+
+		ops = append(ops, op)
+	}
+	registry.scope = operationScope{}
+
+	apiImports := make([]string, 0, len(usedTypes))
+	for name := range usedTypes {
+		apiImports = append(apiImports, name)
+	}
+	sort.Strings(apiImports)
+
+	return ops, apiImports, registry, nil
+}
+
+func ensureUniqueOperationName(name string, ops []Operation) string {
+	used := map[string]struct{}{}
+	for _, op := range ops {
+		used[op.Name] = struct{}{}
+	}
+	if _, ok := used[name]; !ok {
+		return name
+	}
+	idx := 2
+	candidate := fmt.Sprintf("%s%d", name, idx)
+	for {
+		if _, ok := used[candidate]; !ok {
+			return candidate
+		}
+		idx++
+		candidate = fmt.Sprintf("%s%d", name, idx)
+	}
+}
+
+func buildQuerySchema(params []RawParam, page *PaginationProfile) *openapi3.SchemaRef {
+	schema := &openapi3.Schema{Type: typesOf("object"), Properties: map[string]*openapi3.SchemaRef{}}
+	for _, param := range params {
+		if page.isParamName(param.Name) {
+			continue
+		}
+		propSchema := schemaOrAny(param.Schema)
+		if param.Description != "" && propSchema.Ref == "" {
+			if propSchema.Value == nil {
+				propSchema.Value = &openapi3.Schema{}
+			}
+			propSchema.Value.Description = param.Description
+		}
+		schema.Properties[param.Name] = propSchema
+		if param.Required {
+			schema.Required = append(schema.Required, param.Name)
+		}
+	}
+	return &openapi3.SchemaRef{Value: schema}
+}
+
+func hasRequiredParams(params []RawParam, page *PaginationProfile) bool {
+	for _, param := range params {
+		if page.isParamName(param.Name) {
+			continue
+		}
+		if param.Required {
+			return true
+		}
+	}
+	return false
+}
+
+func buildQueryParamTypeName(operationName string, groupName string) string {
+	trimmed := strings.TrimSpace(operationName)
+	if trimmed != "" {
+		if strings.HasSuffix(strings.ToLower(trimmed), "param") {
+			return trimmed
+		}
+		return trimmed + "Param"
+	}
+
+	group := strings.TrimSpace(groupName)
+	if group != "" {
+		return group + "Param"
+	}
+	return "Param"
+}
+
+func buildPathParams(params []RawParam, registry *TypeRegistry) []Param {
+	result := make([]Param, 0, len(params))
+	usedNames := map[string]struct{}{}
+	for _, param := range params {
+		typeName := registry.SchemaToType(param.Schema, nil)
+		varName := sanitizeIdentifier(param.Name)
+		if varName == "data" || varName == "params" {
+			varName = varName + "Param"
+		}
+		if _, ok := usedNames[varName]; ok {
+			varName = fmt.Sprintf("%sParam", varName)
+		}
+		usedNames[varName] = struct{}{}
+
+		result = append(result, Param{
+			Name:        param.Name,
+			VarName:     varName,
+			Type:        typeName,
+			Description: param.Description,
+			Required:    param.Required,
+		})
+	}
+
+	return result
+}
+
+func resolveReturnType(opName string, schemaRef *openapi3.SchemaRef, registry *TypeRegistry, profile PaginationProfile, isPageQuery bool, envelope Envelope) (ReturnInfo, []string) {
+	dataSchema := extractEnvelopeData(schemaRef, registry, envelope)
+	if dataSchema == nil || isEmptySchema(dataSchema) {
+		return ReturnInfo{Type: "void", IsVoid: true}, nil
+	}
+
+	schema := dataSchema
+	if schema.Ref != "" {
+		if isPageQuery {
+			resolved := derefSchemaRef(schema, registry)
+			if resolved != nil && resolved.Value != nil && resolved.Value.Type != nil && resolved.Value.Type.Is("array") {
+				return pageReturnInfo(profile, resolved.Value.Items, registry)
+			}
+		}
+		name, err := registry.directedRef(schema.Ref)
+		if err != nil {
+			defer registry.enterSchema(schema)()
+			registry.fallback(FallbackUnresolvedRef, "%v", err)
+			return ReturnInfo{Type: "any", IsVoid: false}, nil
+		}
+		return ReturnInfo{Type: name}, []string{name}
+	}
+
+	if schema.Value == nil {
+		defer registry.enterSchema(schema)()
+		registry.fallback(FallbackEmptySchema, "schema has no value")
+		return ReturnInfo{Type: "any"}, nil
+	}
+
+	if listItems := extractPageListItems(schema, registry, profile); listItems != nil {
+		return pageReturnInfo(profile, listItems, registry)
+	}
+
+	if schema.Value.Type != nil && schema.Value.Type.Is("array") {
+		if isPageQuery {
+			return pageReturnInfo(profile, schema.Value.Items, registry)
+		}
+		itemType := registry.SchemaToType(schema.Value.Items, nil)
+		used := collectTypeNamesFromSchema(schema.Value.Items, registry)
+		return ReturnInfo{Type: itemType + "[]"}, used
+	}
+
+	inlineName := registry.RegisterInline(opName+"Result", schema, "")
+	return ReturnInfo{Type: inlineName}, []string{inlineName}
+}
+
+func collectTypeNamesFromSchema(schemaRef *openapi3.SchemaRef, registry *TypeRegistry) []string {
+	if schemaRef == nil {
+		return nil
+	}
+	if schemaRef.Ref != "" {
+		name, err := registry.directedRef(schemaRef.Ref)
+		if err != nil {
+			return nil
+		}
+		return []string{name}
+	}
+
+	schema := schemaRef.Value
+	if schema == nil {
+		return nil
+	}
+
+	var names []string
+	if schema.Items != nil {
+		names = append(names, collectTypeNamesFromSchema(schema.Items, registry)...)
+	}
+
+	for _, prop := range schema.Properties {
+		if registry.direction.omitsProperty(prop) {
+			continue
+		}
+		names = append(names, collectTypeNamesFromSchema(prop, registry)...)
+	}
+
+	return uniqueStrings(names)
+}
+
+func derefSchemaRef(schemaRef *openapi3.SchemaRef, registry *TypeRegistry) *openapi3.SchemaRef {
+	if schemaRef == nil || registry == nil {
+		return schemaRef
+	}
+	if schemaRef.Ref == "" {
+		return schemaRef
+	}
+	resolved := registry.resolveRefSchema(schemaRef.Ref)
+	if resolved != nil {
+		return resolved
+	}
+	return schemaRef
+}
+
+func isEmptySchema(schemaRef *openapi3.SchemaRef) bool {
+	if schemaRef == nil {
+		return true
+	}
+	if schemaRef.Ref != "" {
+		return false
+	}
+	schema := schemaRef.Value
+	if schema == nil {
+		return true
+	}
+	if schema.Type != nil && len(*schema.Type) > 0 {
+		return false
+	}
+	if len(schema.Properties) > 0 || schema.Items != nil || len(schema.AllOf) > 0 || len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 || len(schema.Enum) > 0 {
+		return false
+	}
+	return true
+}
+
+func buildErrorText(summary string) string {
+	trimmed := strings.TrimSpace(summary)
+	if trimmed == "" {
+		return "请求失败"
+	}
+	if strings.HasSuffix(trimmed, "失败") {
+		return trimmed
+	}
+	return trimmed + "失败"
+}
+
+func formatRawParamNames(params []RawParam, page *PaginationProfile) string {
+	if len(params) == 0 {
+		return "-"
+	}
+	names := make([]string, 0, len(params))
+	for _, param := range params {
+		if page.isParamName(param.Name) {
+			continue
+		}
+		if param.Name == "" {
+			continue
+		}
+		names = append(names, param.Name)
+	}
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ",")
+}
+
+func formatQueryLog(query *QueryInfo, page *PaginationProfile) string {
+	if query == nil {
+		return "-"
+	}
+	suffix := ""
+	if query.Optional {
+		suffix = "?"
+	}
+	pageSuffix := ""
+	if page != nil {
+		pageSuffix = "+" + page.ParamType
+	}
+	return query.TypeName + suffix + pageSuffix
+}
+
+func formatBodyLog(body *BodyInfo) string {
+	if body == nil {
+		return "-"
+	}
+	suffix := ""
+	if body.Optional {
+		suffix = "?"
+	}
+	formSuffix := ""
+	if body.IsForm {
+		formSuffix = "(form)"
+	}
+	return body.TypeName + suffix + formSuffix
+}
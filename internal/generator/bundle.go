@@ -0,0 +1,358 @@
+package generator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func renderTypeEntries(defs []*TypeDef, registry *TypeRegistry) (map[string]renderedTypeEntry, []string) {
+	entries := make(map[string]renderedTypeEntry, len(defs))
+	order := make([]string, 0, len(defs))
+	for _, def := range defs {
+		if def == nil || def.Name == "" {
+			continue
+		}
+		stopImports := registry.trackImports()
+		content, deps := RenderType(def, registry)
+		imports := stopImports()
+		if content == "" {
+			continue
+		}
+		entries[def.Name] = renderedTypeEntry{
+			Def:     def,
+			Content: content,
+			Deps:    deps,
+			Values:  append(registry.enumValueNames(def), registry.typeGuardNames(def)...),
+			Imports: imports,
+		}
+		order = append(order, def.Name)
+	}
+	return entries, order
+}
+
+// usedRootImports lists the root index types imported by any group, e.g. scalar helper types.
+func usedRootImports(contexts map[string]*groupGenerationContext) []string {
+	var names []string
+	for _, groupCtx := range contexts {
+		for _, op := range groupCtx.typedOps {
+			names = append(names, rootImportNames(op.Imports)...)
+		}
+		for _, entry := range groupCtx.typeEntries {
+			names = append(names, rootImportNames(entry.Imports)...)
+		}
+	}
+	return uniqueStrings(names)
+}
+
+func buildModelRedirectPlan(groupNames []string, contexts map[string]*groupGenerationContext) map[string]map[string]string {
+	signatureToGroups := map[string][]string{}
+	signatureToTypeName := map[string]string{}
+
+	for _, groupName := range groupNames {
+		groupCtx := contexts[groupName]
+		if groupCtx == nil {
+			continue
+		}
+		for typeName, entry := range groupCtx.typeEntries {
+			signature := typeName + "\x1f" + entry.Content
+			signatureToGroups[signature] = append(signatureToGroups[signature], groupName)
+			signatureToTypeName[signature] = typeName
+		}
+	}
+
+	redirectsByGroup := map[string]map[string]string{}
+	for signature, groups := range signatureToGroups {
+		if len(groups) < 2 {
+			continue
+		}
+		sortedGroups := uniqueStrings(groups)
+		if len(sortedGroups) < 2 {
+			continue
+		}
+		canonicalGroup := sortedGroups[0]
+		typeName := signatureToTypeName[signature]
+		for _, groupName := range sortedGroups[1:] {
+			if redirectsByGroup[groupName] == nil {
+				redirectsByGroup[groupName] = map[string]string{}
+			}
+			redirectsByGroup[groupName][typeName] = canonicalGroup
+		}
+	}
+
+	return redirectsByGroup
+}
+
+func renderGroupModelBundle(groupName string, groupCtx *groupGenerationContext, redirects map[string]string) (string, int) {
+	if groupCtx == nil {
+		return "", 0
+	}
+	if len(groupCtx.typeOrder) == 0 {
+		return "", 0
+	}
+	if redirects == nil {
+		redirects = map[string]string{}
+	}
+
+	localDefs := make([]*TypeDef, 0, len(groupCtx.typeOrder))
+	localTypeEntryByName := map[string]renderedTypeEntry{}
+	for _, typeName := range groupCtx.typeOrder {
+		if _, redirected := redirects[typeName]; redirected {
+			continue
+		}
+		entry, exists := groupCtx.typeEntries[typeName]
+		if !exists || entry.Def == nil {
+			continue
+		}
+		localDefs = append(localDefs, entry.Def)
+		localTypeEntryByName[typeName] = entry
+	}
+
+	neededRedirectImports := map[string]map[string]struct{}{}
+	for _, entry := range localTypeEntryByName {
+		for _, dep := range entry.Deps {
+			sourceGroup, redirected := redirects[dep]
+			if !redirected {
+				continue
+			}
+			if sourceGroup == "" || sourceGroup == groupName {
+				continue
+			}
+			if neededRedirectImports[sourceGroup] == nil {
+				neededRedirectImports[sourceGroup] = map[string]struct{}{}
+			}
+			neededRedirectImports[sourceGroup][dep] = struct{}{}
+		}
+	}
+
+	redirectedExports := map[string]map[string]struct{}{}
+	for typeName, sourceGroup := range redirects {
+		if sourceGroup == "" || sourceGroup == groupName {
+			continue
+		}
+		if redirectedExports[sourceGroup] == nil {
+			redirectedExports[sourceGroup] = map[string]struct{}{}
+		}
+		redirectedExports[sourceGroup][typeName] = struct{}{}
+	}
+
+	localModelContent, localModelLines := renderModelDefinitions(localDefs, groupCtx.registry)
+	rootImports := collectExtendsImports(localDefs)
+	var typeImports []TypeImport
+	for _, entry := range localTypeEntryByName {
+		rootImports = append(rootImports, rootImportNames(entry.Imports)...)
+		typeImports = append(typeImports, entry.Imports...)
+	}
+	rootImports = uniqueStrings(rootImports)
+	externalImports := renderTypeImports(typeImports)
+	if len(rootImports) == 0 && externalImports == "" && len(neededRedirectImports) == 0 && len(redirectedExports) == 0 {
+		if localModelLines == 0 {
+			return "", 0
+		}
+		return localModelContent, localModelLines
+	}
+
+	var b strings.Builder
+	if len(rootImports) > 0 {
+		b.WriteString("import type { " + strings.Join(rootImports, ", ") + " } from '@/api';\n")
+	}
+	b.WriteString(externalImports)
+
+	importSources := make([]string, 0, len(neededRedirectImports))
+	for sourceGroup := range neededRedirectImports {
+		importSources = append(importSources, sourceGroup)
+	}
+	sort.Strings(importSources)
+	for _, sourceGroup := range importSources {
+		importedNames := mapKeysSorted(neededRedirectImports[sourceGroup])
+		if len(importedNames) == 0 {
+			continue
+		}
+		b.WriteString("import type { " + strings.Join(importedNames, ", ") + " } from '../../" + sourceGroup + "/model';\n")
+	}
+
+	exportSources := make([]string, 0, len(redirectedExports))
+	for sourceGroup := range redirectedExports {
+		exportSources = append(exportSources, sourceGroup)
+	}
+	sort.Strings(exportSources)
+	if len(exportSources) > 0 && b.Len() > 0 {
+		b.WriteString("\n")
+	}
+	for _, sourceGroup := range exportSources {
+		var valueNames, typeNames []string
+		for _, name := range mapKeysSorted(redirectedExports[sourceGroup]) {
+			if values := groupCtx.typeEntries[name].Values; len(values) > 0 {
+				valueNames = append(valueNames, values...)
+			} else {
+				typeNames = append(typeNames, name)
+			}
+		}
+		// A value export also re-exports the type of the same name.
+		if len(valueNames) > 0 {
+			b.WriteString("export { " + strings.Join(valueNames, ", ") + " } from '../../" + sourceGroup + "/model';\n")
+		}
+		if len(typeNames) > 0 {
+			b.WriteString("export type { " + strings.Join(typeNames, ", ") + " } from '../../" + sourceGroup + "/model';\n")
+		}
+	}
+
+	if localModelLines > 0 {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(localModelContent)
+	}
+
+	finalContent := b.String()
+	if finalContent == "" {
+		return "", 0
+	}
+	return finalContent, countLines(finalContent)
+}
+
+func renderTypeFile(content string, deps []string) string {
+	if len(deps) == 0 {
+		return content
+	}
+	var b strings.Builder
+	b.WriteString("import type { ")
+	b.WriteString(strings.Join(deps, ", "))
+	b.WriteString(" } from './index';\n\n")
+	b.WriteString(content)
+	return b.String()
+}
+
+func extractTypeNames(defs []*TypeDef) []string {
+	names := make([]string, 0, len(defs))
+	for _, def := range defs {
+		if def == nil || def.Name == "" {
+			continue
+		}
+		names = append(names, def.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func renderModelBundle(defs []*TypeDef, registry *TypeRegistry) (string, int) {
+	if len(defs) == 0 {
+		return "", 0
+	}
+	var b strings.Builder
+	if rootImports := collectExtendsImports(defs); len(rootImports) > 0 {
+		b.WriteString("import type { " + strings.Join(rootImports, ", ") + " } from '@/api';\n\n")
+	}
+	modelDefinitions, _ := renderModelDefinitions(defs, registry)
+	if modelDefinitions != "" {
+		b.WriteString(modelDefinitions)
+	}
+	content := b.String()
+	if content == "" {
+		return "", 0
+	}
+	return content, countLines(content)
+}
+
+func renderModelDefinitions(defs []*TypeDef, registry *TypeRegistry) (string, int) {
+	if len(defs) == 0 {
+		return "", 0
+	}
+	var b strings.Builder
+	for idx, def := range defs {
+		content, _ := RenderType(def, registry)
+		if content == "" {
+			continue
+		}
+		if idx > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(content)
+	}
+	modelContent := b.String()
+	if modelContent == "" {
+		return "", 0
+	}
+	return modelContent, countLines(modelContent)
+}
+
+func expandRegistryReferences(registry *TypeRegistry) {
+	if registry == nil {
+		return
+	}
+	visitedRefs := map[string]struct{}{}
+	visitedSchemas := map[*openapi3.Schema]struct{}{}
+	for {
+		before := len(registry.typeOrder)
+		defs := registry.Types()
+		for _, def := range defs {
+			walkSchemaRefs(def.Schema, registry, visitedRefs, visitedSchemas)
+		}
+		after := len(registry.typeOrder)
+		if after == before {
+			return
+		}
+	}
+}
+
+// collectExtendsImports lists the shared '@/api' base types (e.g. PageParam) extended by the given definitions.
+func collectExtendsImports(defs []*TypeDef) []string {
+	var names []string
+	for _, def := range defs {
+		if def == nil {
+			continue
+		}
+		names = append(names, def.Extends...)
+	}
+	return uniqueStrings(names)
+}
+
+func walkSchemaRefs(schemaRef *openapi3.SchemaRef, registry *TypeRegistry, visitedRefs map[string]struct{}, visitedSchemas map[*openapi3.Schema]struct{}) {
+	if schemaRef == nil || registry == nil {
+		return
+	}
+	if schemaRef.Ref != "" {
+		if _, ok := visitedRefs[schemaRef.Ref]; ok {
+			return
+		}
+		visitedRefs[schemaRef.Ref] = struct{}{}
+		_, _ = registry.RegisterRef(schemaRef.Ref)
+		if resolved := registry.resolveRefSchema(schemaRef.Ref); resolved != nil {
+			walkSchemaRefs(resolved, registry, visitedRefs, visitedSchemas)
+		}
+		return
+	}
+	schema := schemaRef.Value
+	if schema == nil {
+		return
+	}
+	if _, ok := visitedSchemas[schema]; ok {
+		return
+	}
+	visitedSchemas[schema] = struct{}{}
+	for _, ref := range schema.AllOf {
+		walkSchemaRefs(ref, registry, visitedRefs, visitedSchemas)
+	}
+	for _, ref := range schema.OneOf {
+		walkSchemaRefs(ref, registry, visitedRefs, visitedSchemas)
+	}
+	for _, ref := range schema.AnyOf {
+		walkSchemaRefs(ref, registry, visitedRefs, visitedSchemas)
+	}
+	if schema.Not != nil {
+		walkSchemaRefs(schema.Not, registry, visitedRefs, visitedSchemas)
+	}
+	if schema.Items != nil {
+		walkSchemaRefs(schema.Items, registry, visitedRefs, visitedSchemas)
+	}
+	for _, ref := range registry.prefixItems(schema) {
+		walkSchemaRefs(ref, registry, visitedRefs, visitedSchemas)
+	}
+	for _, prop := range schema.Properties {
+		walkSchemaRefs(prop, registry, visitedRefs, visitedSchemas)
+	}
+	if schema.AdditionalProperties.Schema != nil {
+		walkSchemaRefs(schema.AdditionalProperties.Schema, registry, visitedRefs, visitedSchemas)
+	}
+}
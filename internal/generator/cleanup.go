@@ -0,0 +1,148 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// pruneStaleOutput removes generated files the rendered output no longer contains: whole group
+// directories without operations and leftover generated files inside active groups, such as an
+// old api_2.ts after a group shrank. It returns the removed files as sorted slash paths relative to
+// outputDir. With dryRun it only reports what it would remove.
+func pruneStaleOutput(outputDir string, output *renderedOutput, groupNames []string, dryRun bool) ([]string, error) {
+	stale, err := pruneStaleGroupDirs(outputDir, groupNames, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	files, err := pruneStaleGroupFiles(outputDir, output, groupNames, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	stale = append(stale, files...)
+	sort.Strings(stale)
+	return stale, nil
+}
+
+// pruneStaleGroupDirs removes generated group directories that no longer have operations and returns
+// the removed files as slash paths relative to outputDir. With dryRun it only reports what it would remove.
+func pruneStaleGroupDirs(outputDir string, groupNames []string, dryRun bool) ([]string, error) {
+	entries, err := os.ReadDir(outputDir)
+	if err != nil {
+		if dryRun && errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read output dir failed: %w", err)
+	}
+
+	activeGroups := make(map[string]struct{}, len(groupNames))
+	for _, groupName := range groupNames {
+		activeGroups[groupName] = struct{}{}
+	}
+
+	var stale []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if _, isActive := activeGroups[name]; isActive {
+			continue
+		}
+
+		groupDirPath := filepath.Join(outputDir, name)
+		if !looksLikeGeneratedGroupDir(groupDirPath) {
+			continue
+		}
+		files, err := listFiles(outputDir, name)
+		if err != nil {
+			return nil, fmt.Errorf("scan stale group dir %s failed: %w", groupDirPath, err)
+		}
+		stale = append(stale, files...)
+		if dryRun {
+			continue
+		}
+		if err := os.RemoveAll(groupDirPath); err != nil {
+			return nil, fmt.Errorf("remove stale group dir %s failed: %w", groupDirPath, err)
+		}
+	}
+
+	return stale, nil
+}
+
+// pruneStaleGroupFiles removes the files inside active group directories that the generator owns
+// but the rendered output does not contain, then any directory left empty. Files with other
+// names, such as a hand-written orders/custom.ts, are never touched.
+func pruneStaleGroupFiles(outputDir string, output *renderedOutput, groupNames []string, dryRun bool) ([]string, error) {
+	generated := make(map[string]struct{}, len(output.Files))
+	for _, file := range output.Files {
+		generated[file.Path] = struct{}{}
+	}
+
+	var stale []string
+	for _, groupName := range groupNames {
+		groupDirPath := filepath.Join(outputDir, groupName)
+		if _, err := os.Stat(groupDirPath); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		files, err := listFiles(outputDir, groupName)
+		if err != nil {
+			return nil, fmt.Errorf("scan group dir %s failed: %w", groupDirPath, err)
+		}
+		for _, file := range files {
+			if _, ok := generated[file]; ok || !isGeneratedGroupFile(strings.TrimPrefix(file, groupName+"/")) {
+				continue
+			}
+			stale = append(stale, file)
+			if dryRun {
+				continue
+			}
+			target := filepath.Join(outputDir, filepath.FromSlash(file))
+			if err := os.Remove(target); err != nil {
+				return nil, fmt.Errorf("remove stale file %s failed: %w", target, err)
+			}
+			removeEmptyDirs(filepath.Dir(target), groupDirPath)
+		}
+	}
+	return stale, nil
+}
+
+// generatedGroupFilePattern matches the files a group directory can hold, relative to it.
+var generatedGroupFilePattern = regexp.MustCompile(`^(index\.ts|api_[1-9][0-9]*\.ts|model/index\.ts)$`)
+
+// isGeneratedGroupFile reports whether name, a slash path relative to a group directory, is one
+// the generator writes: index.ts, api_<n>.ts or model/index.ts.
+func isGeneratedGroupFile(name string) bool {
+	return generatedGroupFilePattern.MatchString(name)
+}
+
+// removeEmptyDirs removes dir and its parents up to, but not including, stop while they are
+// empty. os.Remove refuses non-empty directories, which ends the walk.
+func removeEmptyDirs(dir string, stop string) {
+	for dir != stop && strings.HasPrefix(dir, stop+string(filepath.Separator)) {
+		if os.Remove(dir) != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+func looksLikeGeneratedGroupDir(groupDirPath string) bool {
+	if groupDirPath == "" {
+		return false
+	}
+	info, err := os.Stat(filepath.Join(groupDirPath, "index.ts"))
+	if err == nil && !info.IsDir() {
+		return true
+	}
+	modelInfo, modelErr := os.Stat(filepath.Join(groupDirPath, "model", "index.ts"))
+	if modelErr == nil && !modelInfo.IsDir() {
+		return true
+	}
+	return false
+}
@@ -24,7 +24,7 @@ func TestPruneStaleGroupDirs_RemovesStaleGeneratedDir(t *testing.T) {
 		t.Fatalf("write stale index failed: %v", err)
 	}
 
-	if err := pruneStaleGroupDirs(outputDir, []string{"sysApi"}); err != nil {
+	if _, err := pruneStaleGroupDirs(outputDir, []string{"sysApi"}, false); err != nil {
 		t.Fatalf("pruneStaleGroupDirs returned error: %v", err)
 	}
 
@@ -46,7 +46,7 @@ func TestPruneStaleGroupDirs_IgnoreNonGeneratedDir(t *testing.T) {
 		t.Fatalf("write manual file failed: %v", err)
 	}
 
-	if err := pruneStaleGroupDirs(outputDir, []string{"users"}); err != nil {
+	if _, err := pruneStaleGroupDirs(outputDir, []string{"users"}, false); err != nil {
 		t.Fatalf("pruneStaleGroupDirs returned error: %v", err)
 	}
 
@@ -54,3 +54,38 @@ func TestPruneStaleGroupDirs_IgnoreNonGeneratedDir(t *testing.T) {
 		t.Fatalf("non-generated dir should remain: %v", err)
 	}
 }
+
+func TestPruneStaleOutput_RemovesLeftoverFilesInActiveGroup(t *testing.T) {
+	outputDir := t.TempDir()
+	groupDir := filepath.Join(outputDir, "users")
+	if err := os.MkdirAll(filepath.Join(groupDir, "model", "legacy"), 0o755); err != nil {
+		t.Fatalf("create group dir failed: %v", err)
+	}
+	for _, name := range []string{"index.ts", "api_1.ts", "api_2.ts", "model/index.ts", "model/legacy/User.ts", "custom.ts", "README.md"} {
+		if err := os.WriteFile(filepath.Join(groupDir, filepath.FromSlash(name)), []byte("export {}"), 0o644); err != nil {
+			t.Fatalf("write %s failed: %v", name, err)
+		}
+	}
+	output := &renderedOutput{}
+	output.addFile("users/index.ts", "export * from './api_1';\n")
+	output.addFile("users/api_1.ts", "export {}\n")
+
+	removed, err := pruneStaleOutput(outputDir, output, []string{"users"}, false)
+	if err != nil {
+		t.Fatalf("pruneStaleOutput returned error: %v", err)
+	}
+
+	if len(removed) != 2 || removed[0] != "users/api_2.ts" || removed[1] != "users/model/index.ts" {
+		t.Fatalf("unexpected removed files: %v", removed)
+	}
+	for _, name := range []string{"api_2.ts", "model/index.ts"} {
+		if _, err := os.Stat(filepath.Join(groupDir, filepath.FromSlash(name))); !os.IsNotExist(err) {
+			t.Fatalf("%s should be removed, stat err=%v", name, err)
+		}
+	}
+	for _, name := range []string{"index.ts", "api_1.ts", "model/legacy/User.ts", "custom.ts", "README.md"} {
+		if _, err := os.Stat(filepath.Join(groupDir, filepath.FromSlash(name))); err != nil {
+			t.Fatalf("%s should remain: %v", name, err)
+		}
+	}
+}
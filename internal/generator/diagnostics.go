@@ -0,0 +1,239 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Fallback kinds.
+const (
+	// FallbackUnresolvedRef is a $ref that does not resolve to a component schema.
+	FallbackUnresolvedRef = "unresolved-ref"
+	// FallbackEmptySchema is a schema without type information, or an array without items.
+	FallbackEmptySchema = "empty-schema"
+)
+
+// Fallback records a schema the generator could not type and emitted as any.
+type Fallback struct {
+	Kind string
+	// Operation, Method and Path identify the operation the schema belongs to; empty for
+	// component schemas, which may be shared by several operations.
+	Operation string
+	Method    string
+	Path      string
+	// Type is the TypeScript type being rendered, when the fallback sits inside a named type.
+	Type string
+	// Pointer is the JSON pointer of the schema (or its closest known parent) in the OpenAPI 3
+	// document; Swagger 2 specs are pointed at after conversion.
+	Pointer string
+	Message string
+}
+
+func (f Fallback) String() string {
+	var parts []string
+	if f.Operation != "" {
+		parts = append(parts, fmt.Sprintf("%s %s (%s)", strings.ToUpper(f.Method), f.Path, f.Operation))
+	}
+	if f.Type != "" {
+		parts = append(parts, f.Type)
+	}
+	if f.Pointer != "" {
+		parts = append(parts, f.Pointer)
+	}
+	parts = append(parts, f.Message)
+	return strings.Join(parts, ": ")
+}
+
+// StrictError is returned by Generate in strict mode when any schema fell back to any.
+type StrictError struct {
+	Fallbacks []Fallback
+}
+
+func (e *StrictError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "strict mode: %d schema(s) fell back to any:", len(e.Fallbacks))
+	for _, fallback := range e.Fallbacks {
+		b.WriteString("\n  " + fallback.String())
+	}
+	return b.String()
+}
+
+// operationScope identifies the operation whose types are being registered or rendered.
+type operationScope struct {
+	Operation string
+	Method    string
+	Path      string
+}
+
+func (r *TypeRegistry) fallback(kind string, format string, args ...any) {
+	fallback := Fallback{
+		Kind:      kind,
+		Operation: r.scope.Operation,
+		Method:    r.scope.Method,
+		Path:      r.scope.Path,
+		Type:      r.warnScope,
+		Pointer:   r.currentPointer(),
+		Message:   fmt.Sprintf(format, args...),
+	}
+	if _, ok := r.fallbackSeen[fallback]; ok {
+		return
+	}
+	r.fallbackSeen[fallback] = struct{}{}
+	r.fallbacks = append(r.fallbacks, fallback)
+}
+
+// Fallbacks returns the distinct any fallbacks recorded while registering and rendering types.
+func (r *TypeRegistry) Fallbacks() []Fallback {
+	return append([]Fallback{}, r.fallbacks...)
+}
+
+// enterSchema pushes schemaRef so fallbacks inside it can be located; call the result to pop.
+func (r *TypeRegistry) enterSchema(schemaRef *openapi3.SchemaRef) func() {
+	r.schemaStack = append(r.schemaStack, schemaRef)
+	return func() { r.schemaStack = r.schemaStack[:len(r.schemaStack)-1] }
+}
+
+// currentPointer returns the JSON pointer of the innermost schema on the stack that appears in
+// the document. Synthesized schemas such as query parameter objects resolve to their parent.
+func (r *TypeRegistry) currentPointer() string {
+	if r.schemaPointers == nil {
+		r.schemaPointers = indexSchemaPointers(r.doc)
+	}
+	for idx := len(r.schemaStack) - 1; idx >= 0; idx-- {
+		if pointer, ok := r.schemaPointers[r.schemaStack[idx]]; ok {
+			return pointer
+		}
+	}
+	return ""
+}
+
+// indexSchemaPointers maps every schema reference in the document to its first JSON pointer.
+// Components are indexed before paths so shared schemas point at their definition.
+func indexSchemaPointers(doc *openapi3.T) map[*openapi3.SchemaRef]string {
+	pointers := map[*openapi3.SchemaRef]string{}
+	if doc == nil {
+		return pointers
+	}
+
+	var walk func(schemaRef *openapi3.SchemaRef, pointer string)
+	walk = func(schemaRef *openapi3.SchemaRef, pointer string) {
+		if schemaRef == nil {
+			return
+		}
+		if _, ok := pointers[schemaRef]; ok {
+			return
+		}
+		pointers[schemaRef] = pointer
+		schema := schemaRef.Value
+		if schemaRef.Ref != "" || schema == nil {
+			return
+		}
+		for _, name := range sortedSchemaNames(schema.Properties) {
+			walk(schema.Properties[name], pointer+"/properties/"+escapeJSONPointer(name))
+		}
+		walk(schema.Items, pointer+"/items")
+		walk(schema.AdditionalProperties.Schema, pointer+"/additionalProperties")
+		walk(schema.Not, pointer+"/not")
+		for idx, ref := range schema.AllOf {
+			walk(ref, pointer+"/allOf/"+strconv.Itoa(idx))
+		}
+		for idx, ref := range schema.OneOf {
+			walk(ref, pointer+"/oneOf/"+strconv.Itoa(idx))
+		}
+		for idx, ref := range schema.AnyOf {
+			walk(ref, pointer+"/anyOf/"+strconv.Itoa(idx))
+		}
+	}
+	walkContent := func(content openapi3.Content, pointer string) {
+		mediaTypes := make([]string, 0, len(content))
+		for mediaType := range content {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+		sort.Strings(mediaTypes)
+		for _, mediaType := range mediaTypes {
+			if media := content[mediaType]; media != nil {
+				walk(media.Schema, pointer+"/"+escapeJSONPointer(mediaType)+"/schema")
+			}
+		}
+	}
+	walkParameters := func(params openapi3.Parameters, pointer string) {
+		for idx, param := range params {
+			if param != nil && param.Value != nil {
+				walk(param.Value.Schema, pointer+"/"+strconv.Itoa(idx)+"/schema")
+			}
+		}
+	}
+
+	if doc.Components != nil {
+		for _, name := range sortedSchemaNames(doc.Components.Schemas) {
+			walk(doc.Components.Schemas[name], "/components/schemas/"+escapeJSONPointer(name))
+		}
+	}
+	if doc.Paths == nil {
+		return pointers
+	}
+	for _, path := range doc.Paths.InMatchingOrder() {
+		item := doc.Paths.Value(path)
+		itemPointer := "/paths/" + escapeJSONPointer(path)
+		walkParameters(item.Parameters, itemPointer+"/parameters")
+		for _, entry := range operationsForPathItem(item) {
+			op := entry.Operation
+			if op == nil {
+				continue
+			}
+			opPointer := itemPointer + "/" + entry.Method
+			walkParameters(op.Parameters, opPointer+"/parameters")
+			if op.RequestBody != nil && op.RequestBody.Value != nil {
+				walkContent(op.RequestBody.Value.Content, opPointer+"/requestBody/content")
+			}
+			if op.Responses == nil {
+				continue
+			}
+			statuses := make([]string, 0, op.Responses.Len())
+			for status := range op.Responses.Map() {
+				statuses = append(statuses, status)
+			}
+			sort.Strings(statuses)
+			for _, status := range statuses {
+				if response := op.Responses.Value(status); response != nil && response.Value != nil {
+					walkContent(response.Value.Content, opPointer+"/responses/"+escapeJSONPointer(status)+"/content")
+				}
+			}
+		}
+	}
+	return pointers
+}
+
+func sortedSchemaNames(schemas openapi3.Schemas) []string {
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func escapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// sortFallbacks orders fallbacks by pointer, then operation, for stable reports.
+func sortFallbacks(fallbacks []Fallback) {
+	sort.SliceStable(fallbacks, func(i, j int) bool {
+		a, b := fallbacks[i], fallbacks[j]
+		if a.Pointer != b.Pointer {
+			return a.Pointer < b.Pointer
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		return a.Message < b.Message
+	})
+}
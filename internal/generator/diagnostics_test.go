@@ -0,0 +1,59 @@
+package generator
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestGenerate_ReportsAnyFallbacks(t *testing.T) {
+	doc := buildFallbackDoc()
+
+	report, err := New(doc, Options{OutputDir: filepath.Join(t.TempDir(), "api")}).Generate()
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	want := []Fallback{
+		{Kind: FallbackEmptySchema, Type: "Blob", Pointer: "/components/schemas/Blob", Message: "schema has no type"},
+		{Kind: FallbackUnresolvedRef, Operation: "createOrder", Method: "post", Path: "/api/v1/orders", Type: "CreateOrderBody",
+			Pointer: "/paths/~1api~1v1~1orders/post/requestBody/content/application~1json/schema/properties/customer",
+			Message: "schema ref not found: #/components/schemas/Customer"},
+	}
+	if len(report.Fallbacks) != len(want) {
+		t.Fatalf("unexpected fallbacks: %+v", report.Fallbacks)
+	}
+	for idx, fallback := range report.Fallbacks {
+		if fallback != want[idx] {
+			t.Fatalf("fallback %d:\ngot  %+v\nwant %+v", idx, fallback, want[idx])
+		}
+	}
+
+	_, err = New(doc, Options{OutputDir: filepath.Join(t.TempDir(), "api"), Strict: true}).Generate()
+	var strictErr *StrictError
+	if !errors.As(err, &strictErr) || len(strictErr.Fallbacks) != 2 {
+		t.Fatalf("strict mode should fail with both fallbacks, got %v", err)
+	}
+}
+
+func buildFallbackDoc() *openapi3.T {
+	components := openapi3.NewComponents()
+	components.Schemas = openapi3.Schemas{
+		"Blob": {Value: &openapi3.Schema{Description: "opaque"}},
+	}
+	body := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type: typesOf("object"),
+		Properties: openapi3.Schemas{
+			"customer": {Ref: "#/components/schemas/Customer"},
+			"payload":  {Ref: "#/components/schemas/Blob"},
+		},
+	}}
+
+	doc := &openapi3.T{Components: &components, Paths: openapi3.NewPaths()}
+	doc.Paths.Set("/api/v1/orders", &openapi3.PathItem{Post: &openapi3.Operation{
+		OperationID: "createOrder",
+		RequestBody: &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithJSONSchemaRef(body)},
+	}})
+	return doc
+}
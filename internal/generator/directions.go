@@ -0,0 +1,113 @@
+package generator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// schemaDirection is the way a schema travels in an operation signature. readOnly properties are
+// only ever received and writeOnly properties only ever sent, so each direction leaves one out.
+type schemaDirection int
+
+const (
+	directionNone schemaDirection = iota
+	directionRequest
+	directionResponse
+)
+
+// suffix names the type derived from a component for the direction, e.g. UserCreate.
+func (d schemaDirection) suffix() string {
+	switch d {
+	case directionRequest:
+		return "Create"
+	case directionResponse:
+		return "Read"
+	default:
+		return ""
+	}
+}
+
+// omitsProperty reports whether the direction leaves the property out.
+func (d schemaDirection) omitsProperty(prop *openapi3.SchemaRef) bool {
+	if prop == nil || prop.Value == nil {
+		return false
+	}
+	switch d {
+	case directionRequest:
+		return prop.Value.ReadOnly
+	case directionResponse:
+		return prop.Value.WriteOnly
+	default:
+		return false
+	}
+}
+
+// omittedProperties lists the properties of schema, including those merged in through allOf,
+// that the direction leaves out, in name order.
+func (d schemaDirection) omittedProperties(schema *openapi3.Schema, seen map[*openapi3.Schema]struct{}) []string {
+	if d == directionNone || schema == nil {
+		return nil
+	}
+	if _, ok := seen[schema]; ok {
+		return nil
+	}
+	seen[schema] = struct{}{}
+	var names []string
+	for name, prop := range schema.Properties {
+		if d.omitsProperty(prop) {
+			names = append(names, name)
+		}
+	}
+	for _, part := range schema.AllOf {
+		if part != nil {
+			names = append(names, d.omittedProperties(part.Value, seen)...)
+		}
+	}
+	names = uniqueStrings(names)
+	sort.Strings(names)
+	return names
+}
+
+// directedRef registers a component like RegisterRef. When the registry is building a request
+// or response and the component has properties the direction leaves out, it returns a type
+// derived with Omit instead, registered once per component and direction.
+func (r *TypeRegistry) directedRef(ref string) (string, error) {
+	name, err := r.RegisterRef(ref)
+	if err != nil || r.direction == directionNone {
+		return name, err
+	}
+	schemaRef := r.resolveRefSchema(ref)
+	if _, overridden := r.lookupOverride(schemaRef); overridden {
+		return name, nil
+	}
+	omit := r.direction.omittedProperties(schemaRef.Value, map[*openapi3.Schema]struct{}{})
+	if len(omit) == 0 {
+		return name, nil
+	}
+
+	key := ref + "#" + r.direction.suffix()
+	if derived, ok := r.directedNames[key]; ok {
+		return derived, nil
+	}
+	derived := r.ensureUniqueName(name + r.direction.suffix())
+	r.directedNames[key] = derived
+	r.addType(&TypeDef{
+		Name:     derived,
+		Schema:   schemaRef,
+		Kind:     "derived",
+		omitFrom: name,
+		omit:     omit,
+	})
+	return derived, nil
+}
+
+// omitType renders the type expression of a derived def, e.g. Omit<User, 'id' | 'createdAt'>.
+func omitType(def *TypeDef) string {
+	keys := make([]string, 0, len(def.omit))
+	for _, name := range def.omit {
+		keys = append(keys, "'"+escapeTSString(name)+"'")
+	}
+	return "Omit<" + def.omitFrom + ", " + strings.Join(keys, " | ") + ">"
+}
@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func buildReadWriteOnlyDoc() *openapi3.T {
+	components := openapi3.NewComponents()
+	components.Schemas = openapi3.Schemas{
+		"User": {Value: &openapi3.Schema{
+			Type:     typesOf("object"),
+			Required: []string{"id", "name"},
+			Properties: openapi3.Schemas{
+				"id":       {Value: &openapi3.Schema{Type: typesOf("integer"), ReadOnly: true}},
+				"name":     {Value: &openapi3.Schema{Type: typesOf("string")}},
+				"password": {Value: &openapi3.Schema{Type: typesOf("string"), WriteOnly: true}},
+			},
+		}},
+	}
+	user := &openapi3.SchemaRef{Ref: "#/components/schemas/User"}
+	response := openapi3.NewResponse().
+		WithDescription("ok").
+		WithContent(openapi3.NewContentWithJSONSchemaRef(&openapi3.SchemaRef{Value: &openapi3.Schema{
+			Type:       typesOf("object"),
+			Properties: openapi3.Schemas{"data": user},
+		}}))
+	importBody := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type: typesOf("object"),
+		Properties: openapi3.Schemas{
+			"users": {Value: &openapi3.Schema{Type: typesOf("array"), Items: user}},
+			"token": {Value: &openapi3.Schema{Type: typesOf("string"), ReadOnly: true}},
+		},
+	}}
+
+	doc := &openapi3.T{Components: &components, Paths: openapi3.NewPaths()}
+	doc.Paths.Set("/api/v1/users", &openapi3.PathItem{
+		Post: &openapi3.Operation{
+			Summary:     "创建用户",
+			RequestBody: &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithRequired(true).WithJSONSchemaRef(user)},
+			Responses:   openapi3.NewResponses(openapi3.WithStatus(200, &openapi3.ResponseRef{Value: response})),
+		},
+		Put: &openapi3.Operation{
+			Summary:     "批量导入",
+			RequestBody: &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithJSONSchemaRef(importBody)},
+			Responses:   openapi3.NewResponses(openapi3.WithStatus(200, &openapi3.ResponseRef{Value: openapi3.NewResponse().WithDescription("ok")})),
+		},
+	})
+	return doc
+}
+
+func TestRender_SplitsReadOnlyAndWriteOnlyShapes(t *testing.T) {
+	files, _, err := New(buildReadWriteOnlyDoc(), Options{}).Render(context.Background())
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	model := files["users/model/index.ts"]
+	for _, want := range []string{
+		"export interface User {\n  id: number;\n  name: string;\n  password?: string;\n}\n",
+		"export type UserCreate = Omit<User, 'id'>;\n",
+		"export type UserRead = Omit<User, 'password'>;\n",
+		"  users?: Array<UserCreate>;\n",
+	} {
+		if !strings.Contains(model, want) {
+			t.Fatalf("model is missing %q:\n%s", want, model)
+		}
+	}
+	if strings.Contains(model, "token") {
+		t.Fatalf("readOnly fields should be left out of inline request bodies:\n%s", model)
+	}
+
+	api := files["users/index.ts"]
+	for _, want := range []string{"(data: UserCreate)", "ApiResult<UserRead>"} {
+		if !strings.Contains(api, want) {
+			t.Fatalf("api is missing %q:\n%s", want, api)
+		}
+	}
+}
+
+func TestRender_KeepsComponentWithoutDirectionalFields(t *testing.T) {
+	doc := buildReadWriteOnlyDoc()
+	for _, prop := range doc.Components.Schemas["User"].Value.Properties {
+		prop.Value.ReadOnly, prop.Value.WriteOnly = false, false
+	}
+	files, _, err := New(doc, Options{}).Render(context.Background())
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if model := files["users/model/index.ts"]; strings.Contains(model, "Omit<") {
+		t.Fatalf("no derived types expected:\n%s", model)
+	}
+	if api := files["users/index.ts"]; !strings.Contains(api, "(data: User)") {
+		t.Fatalf("body should use the component:\n%s", api)
+	}
+}
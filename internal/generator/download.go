@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const downloadResultType = "DownloadResult"
+
+// isDownloadResponse reports whether a success response is a file rather than a JSON payload:
+// any non-JSON content type, or a string schema with binary format.
+func isDownloadResponse(contentType string, schemaRef *openapi3.SchemaRef) bool {
+	if schemaRef != nil && schemaRef.Value != nil && schemaRef.Value.Type != nil &&
+		schemaRef.Value.Type.Is("string") && schemaRef.Value.Format == "binary" {
+		return true
+	}
+
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	switch {
+	case mediaType == "", mediaType == "*/*":
+		return false
+	case mediaType == "application/json", strings.HasSuffix(mediaType, "+json"):
+		return false
+	}
+	return true
+}
+
+// hasDownloadOperations reports whether any operation needs the download helpers in the root index.
+func hasDownloadOperations(ops []RawOperation) bool {
+	for _, op := range ops {
+		if op.Download {
+			return true
+		}
+	}
+	return false
+}
+
+// downloadHelpers is appended to the root index when the spec has file download endpoints.
+const downloadHelpers = `/**
+ * 文件下载结果
+ */
+export interface DownloadResult {
+  /** 文件内容 */
+  blob: Blob;
+  /** 从 Content-Disposition 解析出的文件名 */
+  filename?: string;
+}
+
+/**
+ * 解析 Content-Disposition 中的文件名，优先使用 filename*（RFC 5987）
+ */
+export function parseContentDispositionFilename(header?: string | null): string | undefined {
+  if (!header) {
+    return undefined;
+  }
+  const encoded = /filename\*\s*=\s*([^']*)'[^']*'([^;]+)/i.exec(header);
+  if (encoded) {
+    try {
+      return decodeURIComponent(encoded[2].trim().replace(/^"|"$/g, ''));
+    } catch {
+      // 编码非法时回退到 filename 参数
+    }
+  }
+  const plain = /filename\s*=\s*("(?:[^"\\]|\\.)*"|[^;]+)/i.exec(header);
+  if (!plain) {
+    return undefined;
+  }
+  const value = plain[1].trim();
+  return value.startsWith('"') ? value.slice(1, -1).replace(/\\(.)/g, '$1') : value;
+}
+
+/**
+ * 组装文件下载结果
+ */
+export function toDownloadResult(blob: Blob, headers?: Record<string, any>): DownloadResult {
+  return { blob, filename: parseContentDispositionFilename(headers?.['content-disposition']) };
+}
+`
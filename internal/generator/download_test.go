@@ -0,0 +1,57 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestIsDownloadResponse(t *testing.T) {
+	binary := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Format: "binary"}}
+	object := openapi3.NewObjectSchema().NewRef()
+
+	cases := []struct {
+		contentType string
+		schema      *openapi3.SchemaRef
+		want        bool
+	}{
+		{contentType: "application/json", schema: object, want: false},
+		{contentType: "application/problem+json", schema: object, want: false},
+		{contentType: "application/octet-stream", want: true},
+		{contentType: "application/vnd.ms-excel", schema: binary, want: true},
+		{contentType: "*/*", schema: binary, want: true},
+		{contentType: "*/*", schema: object, want: false},
+		{contentType: "", want: false},
+	}
+	for _, tc := range cases {
+		if got := isDownloadResponse(tc.contentType, tc.schema); got != tc.want {
+			t.Fatalf("isDownloadResponse(%q) = %t, want %t", tc.contentType, got, tc.want)
+		}
+	}
+}
+
+func TestRenderOperation_DownloadRequestsBlobWithoutEnvelope(t *testing.T) {
+	op := Operation{
+		Name:      "exportUsers",
+		Method:    "get",
+		Path:      "/api/v1/users/export",
+		Query:     &QueryInfo{TypeName: "ExportUsersParam", Optional: true},
+		Return:    ReturnInfo{Type: downloadResultType, IsDownload: true},
+		ErrorText: "导出用户失败",
+	}
+
+	content := RenderAPIFile([]Operation{op}, []string{"ExportUsersParam"})
+	if !strings.Contains(content, "import { toApiError, toDownloadResult } from '@/api';\n") {
+		t.Fatalf("unexpected runtime imports:\n%s", content)
+	}
+	if strings.Contains(content, "ApiResult") || strings.Contains(content, "success") {
+		t.Fatalf("download operation should skip the envelope:\n%s", content)
+	}
+	if !strings.Contains(content, ".get<Blob>('/api/v1/users/export', { params, responseType: 'blob' })\n") {
+		t.Fatalf("download should request a blob:\n%s", content)
+	}
+	if !strings.Contains(content, "  return toDownloadResult(res.data, res.headers);\n") {
+		t.Fatalf("download should return the blob with its filename:\n%s", content)
+	}
+}
@@ -0,0 +1,203 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Enum styles select how named enum schemas are emitted.
+const (
+	// EnumStyleConst emits the literal union plus an `as const` object (default).
+	EnumStyleConst = "const"
+	// EnumStyleEnum emits a TypeScript enum.
+	EnumStyleEnum = "enum"
+	// EnumStyleUnion emits the literal union only.
+	EnumStyleUnion = "union"
+)
+
+// Extensions written by swag for Go const enums.
+const (
+	enumVarNamesExtension     = "x-enum-varnames"
+	enumCommentsExtension     = "x-enum-comments"
+	enumDescriptionsExtension = "x-enum-descriptions"
+)
+
+var tsIdentifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+func validateEnumStyle(style string) error {
+	switch style {
+	case "", EnumStyleConst, EnumStyleEnum, EnumStyleUnion:
+		return nil
+	default:
+		return fmt.Errorf("unknown enum style %q (expected %s, %s or %s)", style, EnumStyleConst, EnumStyleEnum, EnumStyleUnion)
+	}
+}
+
+// enumMember is one value of a named enum.
+type enumMember struct {
+	Name    string
+	Literal string
+	Label   string
+}
+
+// enumMembers names the values of an enum schema. Names come from x-enum-varnames; string enums
+// without them use their values. ok is false when a value is not a string or number, or when
+// the names are not unique TypeScript identifiers. hasLabels reports whether any member took
+// its label from x-enum-comments or x-enum-descriptions; the others are labelled with their name.
+func enumMembers(schema *openapi3.Schema) (members []enumMember, hasLabels bool, ok bool) {
+	varNames := extensionStrings(schema.Extensions[enumVarNamesExtension])
+	comments, _ := schema.Extensions[enumCommentsExtension].(map[string]any)
+	descriptions := extensionStrings(schema.Extensions[enumDescriptionsExtension])
+
+	var values []any
+	for _, value := range schema.Enum {
+		if value != nil {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 || len(varNames) > 0 && len(varNames) != len(values) {
+		return nil, false, false
+	}
+
+	seen := map[string]struct{}{}
+	for idx, value := range values {
+		var name string
+		switch val := value.(type) {
+		case string:
+			name = val
+		case float64, int:
+		default:
+			return nil, false, false
+		}
+		if len(varNames) > 0 {
+			name = varNames[idx]
+		}
+		if !tsIdentifierPattern.MatchString(name) {
+			return nil, false, false
+		}
+		if _, dup := seen[name]; dup {
+			return nil, false, false
+		}
+		seen[name] = struct{}{}
+
+		label := ""
+		if comment, isString := comments[name].(string); isString {
+			label = strings.TrimSpace(comment)
+		}
+		if label == "" && idx < len(descriptions) {
+			label = strings.TrimSpace(descriptions[idx])
+		}
+		if label != "" {
+			hasLabels = true
+		} else {
+			label = name
+		}
+		members = append(members, enumMember{Name: name, Literal: enumLiteral(value), Label: label})
+	}
+	return members, hasLabels, true
+}
+
+func extensionStrings(value any) []string {
+	items, _ := value.([]any)
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		text, _ := item.(string)
+		values = append(values, text)
+	}
+	return values
+}
+
+// enumValueNames returns the runtime names a named enum exports besides its type: the object or
+// TS enum, and the label map. It is empty for enums emitted as a plain union.
+func (r *TypeRegistry) enumValueNames(def *TypeDef) []string {
+	schema := r.namedEnumSchema(def)
+	if schema == nil {
+		return nil
+	}
+	_, hasLabels, ok := enumMembers(schema)
+	if !ok {
+		return nil
+	}
+	if hasLabels {
+		return []string{def.Name, def.Name + "Labels"}
+	}
+	return []string{def.Name}
+}
+
+// namedEnumSchema returns the enum schema behind def when the enum style emits runtime values for it.
+func (r *TypeRegistry) namedEnumSchema(def *TypeDef) *openapi3.Schema {
+	if r.enumStyle == EnumStyleUnion || def == nil || def.Schema == nil {
+		return nil
+	}
+	schemaRef := def.Schema
+	if schemaRef.Ref != "" {
+		schemaRef = r.resolveRefSchema(schemaRef.Ref)
+	}
+	if schemaRef == nil || schemaRef.Value == nil || len(schemaRef.Value.Enum) == 0 || rendersAsInterface(schemaRef.Value) {
+		return nil
+	}
+	return schemaRef.Value
+}
+
+// renderEnum emits a named enum as the literal union plus an `as const` object, or as a TS enum.
+// Nullable enums always use the object form because a TS enum cannot hold null.
+// When enum comments are present, a NameLabels map from value to label follows for dropdowns.
+func renderEnum(def *TypeDef, schema *openapi3.Schema, style string, description string) (string, bool) {
+	members, hasLabels, ok := enumMembers(schema)
+	if !ok {
+		return "", false
+	}
+	nullable := schema.Nullable
+	for _, value := range schema.Enum {
+		if value == nil {
+			nullable = true
+		}
+	}
+
+	var b strings.Builder
+	if description != "" {
+		b.WriteString("/** " + escapeJSDoc(description) + " */\n")
+	}
+	keyType := def.Name
+	if style == EnumStyleEnum && !nullable {
+		b.WriteString("export enum " + def.Name + " {\n")
+		for _, member := range members {
+			writeEnumMemberComment(&b, member)
+			b.WriteString("  " + member.Name + " = " + member.Literal + ",\n")
+		}
+		b.WriteString("}\n")
+	} else {
+		typeExpr := enumToType(schema.Enum)
+		if schema.Nullable {
+			typeExpr += " | null"
+		}
+		if nullable {
+			keyType = "Exclude<" + def.Name + ", null>"
+		}
+		b.WriteString("export type " + def.Name + " = " + typeExpr + ";\n")
+		b.WriteString("export const " + def.Name + " = {\n")
+		for _, member := range members {
+			writeEnumMemberComment(&b, member)
+			b.WriteString("  " + member.Name + ": " + member.Literal + ",\n")
+		}
+		b.WriteString("} as const;\n")
+	}
+
+	if hasLabels {
+		b.WriteString("export const " + def.Name + "Labels: Record<" + keyType + ", string> = {\n")
+		for _, member := range members {
+			b.WriteString("  [" + def.Name + "." + member.Name + "]: '" + escapeTSString(member.Label) + "',\n")
+		}
+		b.WriteString("};\n")
+	}
+	return b.String(), true
+}
+
+func writeEnumMemberComment(b *strings.Builder, member enumMember) {
+	if member.Label != member.Name {
+		b.WriteString("  /** " + escapeJSDoc(member.Label) + " */\n")
+	}
+}
@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func buildEnumDoc() *openapi3.T {
+	components := openapi3.NewComponents()
+	components.Schemas = openapi3.Schemas{
+		"Status": {Value: &openapi3.Schema{
+			Type:        typesOf("integer"),
+			Description: "用户状态",
+			Enum:        []any{float64(1), float64(2)},
+			Extensions: map[string]any{
+				enumVarNamesExtension: []any{"StatusActive", "StatusDisabled"},
+				enumCommentsExtension: map[string]any{"StatusActive": "启用"},
+			},
+		}},
+		"Role":  {Value: &openapi3.Schema{Type: typesOf("string"), Enum: []any{"admin", "member"}}},
+		"Level": {Value: &openapi3.Schema{Type: typesOf("integer"), Enum: []any{float64(1), float64(2)}}},
+		"Color": {Value: &openapi3.Schema{
+			Type:     typesOf("string"),
+			Nullable: true,
+			Enum:     []any{"red", "blue"},
+			Extensions: map[string]any{
+				enumDescriptionsExtension: []any{"红", "蓝"},
+			},
+		}},
+	}
+	return &openapi3.T{Components: &components, Paths: openapi3.NewPaths()}
+}
+
+func renderEnumComponent(t *testing.T, style string, name string) string {
+	t.Helper()
+	registry := NewTypeRegistry(buildEnumDoc())
+	registry.enumStyle = style
+	if _, err := registry.RegisterRef("#/components/schemas/" + name); err != nil {
+		t.Fatalf("RegisterRef returned error: %v", err)
+	}
+	content, _ := RenderType(registry.Types()[0], registry)
+	return content
+}
+
+func TestRenderType_EnumStyles(t *testing.T) {
+	cases := []struct {
+		style string
+		name  string
+		want  string
+	}{
+		{EnumStyleConst, "Status", `/** 用户状态 */
+export type Status = 1 | 2;
+export const Status = {
+  /** 启用 */
+  StatusActive: 1,
+  StatusDisabled: 2,
+} as const;
+export const StatusLabels: Record<Status, string> = {
+  [Status.StatusActive]: '启用',
+  [Status.StatusDisabled]: 'StatusDisabled',
+};
+`},
+		{EnumStyleEnum, "Status", `/** 用户状态 */
+export enum Status {
+  /** 启用 */
+  StatusActive = 1,
+  StatusDisabled = 2,
+}
+export const StatusLabels: Record<Status, string> = {
+  [Status.StatusActive]: '启用',
+  [Status.StatusDisabled]: 'StatusDisabled',
+};
+`},
+		{EnumStyleUnion, "Status", "/** 用户状态 */\nexport type Status = 1 | 2;\n"},
+		{"", "Role", `export type Role = 'admin' | 'member';
+export const Role = {
+  admin: 'admin',
+  member: 'member',
+} as const;
+`},
+		// Numbers without x-enum-varnames have no names to emit.
+		{"", "Level", "export type Level = 1 | 2;\n"},
+		// A TS enum cannot hold null, so nullable enums keep the object form.
+		{EnumStyleEnum, "Color", `export type Color = 'red' | 'blue' | null;
+export const Color = {
+  /** 红 */
+  red: 'red',
+  /** 蓝 */
+  blue: 'blue',
+} as const;
+export const ColorLabels: Record<Exclude<Color, null>, string> = {
+  [Color.red]: '红',
+  [Color.blue]: '蓝',
+};
+`},
+	}
+	for _, tc := range cases {
+		if got := renderEnumComponent(t, tc.style, tc.name); got != tc.want {
+			t.Fatalf("%s as %q:\n--- got ---\n%s\n--- want ---\n%s", tc.name, tc.style, got, tc.want)
+		}
+	}
+}
+
+func TestEnumValueNames_FollowRenderedExports(t *testing.T) {
+	registry := NewTypeRegistry(buildEnumDoc())
+	for _, name := range []string{"Status", "Role", "Level"} {
+		if _, err := registry.RegisterRef("#/components/schemas/" + name); err != nil {
+			t.Fatalf("RegisterRef returned error: %v", err)
+		}
+	}
+	got := map[string][]string{}
+	for _, def := range registry.Types() {
+		got[def.Name] = registry.enumValueNames(def)
+	}
+	if len(got["Status"]) != 2 || got["Status"][1] != "StatusLabels" || len(got["Role"]) != 1 || len(got["Level"]) != 0 {
+		t.Fatalf("unexpected value names: %v", got)
+	}
+
+	registry.enumStyle = EnumStyleUnion
+	for _, def := range registry.Types() {
+		if names := registry.enumValueNames(def); len(names) != 0 {
+			t.Fatalf("union style exports no values, got %v for %s", names, def.Name)
+		}
+	}
+}
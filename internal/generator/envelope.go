@@ -0,0 +1,254 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	defaultEnvelopeDataPath     = "data"
+	defaultEnvelopeSuccessField = "success"
+	defaultEnvelopeMessageField = "message"
+)
+
+// Envelope describes the response wrapper the backend puts around every payload.
+// The zero value is the go-fiber-admin envelope: {success, data, message}.
+type Envelope struct {
+	// Unwrapped marks responses whose body is the payload itself.
+	Unwrapped bool
+	// DataPath is the dot-separated path of the payload inside the envelope, e.g. "result" or "payload.data".
+	DataPath string
+	// SuccessField names the field checked to decide whether the call succeeded.
+	SuccessField string
+	// SuccessValue is compared with SuccessField using ===; nil means a truthiness check.
+	SuccessValue any
+	// MessageField names the field carrying the error message.
+	MessageField string
+	// ReasonField names the field carrying the machine-readable error reason; empty disables it
+	// for custom envelopes and means "reason" for the go-fiber-admin envelope.
+	ReasonField string
+	// UnwrappedPaths lists path prefixes whose responses carry no envelope even when Unwrapped is false.
+	UnwrappedPaths []string
+}
+
+// forPath returns the envelope that applies to a single operation path.
+func (e Envelope) forPath(path string) Envelope {
+	resolved := e
+	resolved.UnwrappedPaths = nil
+	for _, prefix := range e.UnwrappedPaths {
+		if prefix != "" && strings.HasPrefix(path, prefix) {
+			resolved.Unwrapped = true
+			break
+		}
+	}
+	return resolved
+}
+
+func (e Envelope) dataPath() []string {
+	path := strings.TrimSpace(e.DataPath)
+	if path == "" {
+		path = defaultEnvelopeDataPath
+	}
+	var segments []string
+	for _, segment := range strings.Split(path, ".") {
+		if trimmed := strings.TrimSpace(segment); trimmed != "" {
+			segments = append(segments, trimmed)
+		}
+	}
+	return segments
+}
+
+func (e Envelope) successField() string {
+	if field := strings.TrimSpace(e.SuccessField); field != "" {
+		return field
+	}
+	return defaultEnvelopeSuccessField
+}
+
+func (e Envelope) messageField() string {
+	if field := strings.TrimSpace(e.MessageField); field != "" {
+		return field
+	}
+	return defaultEnvelopeMessageField
+}
+
+// isFiberx reports whether the envelope matches the built-in go-fiber-admin ApiResult shape.
+func (e Envelope) isFiberx() bool {
+	path := e.dataPath()
+	return len(path) == 1 && path[0] == defaultEnvelopeDataPath &&
+		e.successField() == defaultEnvelopeSuccessField &&
+		e.SuccessValue == nil &&
+		e.messageField() == defaultEnvelopeMessageField
+}
+
+func (e Envelope) reasonField() string {
+	if field := strings.TrimSpace(e.ReasonField); field != "" {
+		return field
+	}
+	if e.isFiberx() {
+		return "reason"
+	}
+	return ""
+}
+
+func (e Envelope) successExpr(body string) string {
+	access := memberAccess(body, e.successField(), false)
+	if e.SuccessValue == nil {
+		return access
+	}
+	return access + " === " + tsLiteral(e.SuccessValue)
+}
+
+func (e Envelope) dataExpr(body string) string {
+	expr := body
+	for idx, segment := range e.dataPath() {
+		expr = memberAccess(expr, segment, idx > 0)
+	}
+	return expr
+}
+
+func (e Envelope) messageExpr(body string) string {
+	return memberAccess(body+"?", e.messageField(), false)
+}
+
+// reasonExpr renders the reason lookup, falling back to an empty string when the envelope has no reason field.
+func (e Envelope) reasonExpr(body string) string {
+	if e.reasonField() == "" {
+		return "''"
+	}
+	return memberAccess(body+"?", e.reasonField(), false) + " ?? ''"
+}
+
+func (e Envelope) successFieldType() string {
+	switch e.SuccessValue.(type) {
+	case nil, bool:
+		return "boolean"
+	case string:
+		return "string"
+	default:
+		return "number"
+	}
+}
+
+func memberAccess(object string, field string, optionalChain bool) string {
+	if strings.HasSuffix(object, "?") {
+		object = strings.TrimSuffix(object, "?")
+		optionalChain = true
+	}
+	if isValidIdentifier(field) {
+		if optionalChain {
+			return object + "?." + field
+		}
+		return object + "." + field
+	}
+	key := "['" + escapeTSString(field) + "']"
+	if optionalChain {
+		return object + "?." + key
+	}
+	return object + key
+}
+
+func tsLiteral(value any) string {
+	switch val := value.(type) {
+	case string:
+		return "'" + escapeTSString(val) + "'"
+	case bool:
+		return fmt.Sprintf("%t", val)
+	case float64, float32, int, int64, int32:
+		return fmt.Sprintf("%v", val)
+	default:
+		encoded, err := json.Marshal(val)
+		if err != nil {
+			return "undefined"
+		}
+		return string(encoded)
+	}
+}
+
+// renderResultInterface renders the ApiResult interface for a custom envelope.
+func (e Envelope) renderResultInterface() string {
+	var b strings.Builder
+	b.WriteString("/**\n * 接口统一返回结果\n */\n")
+	b.WriteString("export interface ApiResult<T = any> {\n")
+
+	written := map[string]struct{}{}
+	writeField := func(comment string, name string, optional bool, typeExpr string) {
+		if _, ok := written[name]; ok {
+			return
+		}
+		written[name] = struct{}{}
+		propName := tsPropertyName(name)
+		suffix := ""
+		if optional {
+			suffix = "?"
+		}
+		b.WriteString("  /** " + comment + " */\n")
+		b.WriteString("  " + propName + suffix + ": " + typeExpr + ";\n")
+	}
+
+	writeField("成功标识", e.successField(), false, e.successFieldType())
+
+	dataPath := e.dataPath()
+	dataType := "T"
+	for idx := len(dataPath) - 1; idx > 0; idx-- {
+		dataType = "{ " + tsPropertyName(dataPath[idx]) + "?: " + dataType + " }"
+	}
+	if len(dataPath) > 0 {
+		writeField("返回数据", dataPath[0], true, dataType)
+	}
+
+	if reason := e.reasonField(); reason != "" {
+		writeField("错误原因", reason, false, "string")
+	}
+	writeField("错误消息", e.messageField(), false, "string")
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// extractEnvelopeData returns the payload schema carried inside the envelope, or nil when there is none.
+func extractEnvelopeData(schemaRef *openapi3.SchemaRef, registry *TypeRegistry, envelope Envelope) *openapi3.SchemaRef {
+	if schemaRef == nil {
+		return nil
+	}
+	if envelope.Unwrapped {
+		if isEmptySchema(schemaRef) {
+			return nil
+		}
+		return schemaRef
+	}
+
+	current := schemaRef
+	for _, segment := range envelope.dataPath() {
+		current = lookupEnvelopeProperty(current, segment, registry)
+		if current == nil {
+			return nil
+		}
+	}
+	return current
+}
+
+func lookupEnvelopeProperty(schemaRef *openapi3.SchemaRef, name string, registry *TypeRegistry) *openapi3.SchemaRef {
+	resolved := derefSchemaRef(schemaRef, registry)
+	if resolved == nil || resolved.Value == nil {
+		return nil
+	}
+
+	schema := resolved.Value
+	for _, item := range schema.AllOf {
+		itemSchema := derefSchemaRef(item, registry)
+		if itemSchema == nil || itemSchema.Value == nil {
+			continue
+		}
+		if propSchema, ok := itemSchema.Value.Properties[name]; ok && !isEmptySchema(propSchema) {
+			return propSchema
+		}
+	}
+
+	if propSchema, ok := schema.Properties[name]; ok && !isEmptySchema(propSchema) {
+		return propSchema
+	}
+	return nil
+}
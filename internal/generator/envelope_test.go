@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestResolveReturnType_FollowsCustomEnvelopeDataField(t *testing.T) {
+	registry := NewTypeRegistry(&openapi3.T{})
+	responseSchema := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type: typesOf("object"),
+		Properties: openapi3.Schemas{
+			"code":   {Value: &openapi3.Schema{Type: typesOf("integer")}},
+			"msg":    {Value: &openapi3.Schema{Type: typesOf("string")}},
+			"result": {Value: &openapi3.Schema{Type: typesOf("array"), Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: typesOf("string")}}}},
+		},
+	}}
+
+	envelope := Envelope{DataPath: "result", SuccessField: "code", SuccessValue: float64(0), MessageField: "msg"}
+	returnInfo, _ := resolveReturnType("listNames", responseSchema, registry, fiberxPaginationProfile, false, envelope)
+	if returnInfo.Type != "string[]" {
+		t.Fatalf("unexpected return type: got %s want %s", returnInfo.Type, "string[]")
+	}
+
+	returnInfo, _ = resolveReturnType("listNames", responseSchema, registry, fiberxPaginationProfile, false, Envelope{})
+	if !returnInfo.IsVoid {
+		t.Fatalf("default envelope should not find data in custom envelope: %+v", returnInfo)
+	}
+}
+
+func TestResolveReturnType_UnwrappedUsesWholeResponse(t *testing.T) {
+	registry := NewTypeRegistry(&openapi3.T{})
+	responseSchema := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type:  typesOf("array"),
+		Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: typesOf("integer")}},
+	}}
+
+	returnInfo, _ := resolveReturnType("listIds", responseSchema, registry, fiberxPaginationProfile, false, Envelope{Unwrapped: true})
+	if returnInfo.Type != "number[]" {
+		t.Fatalf("unexpected return type: got %s want %s", returnInfo.Type, "number[]")
+	}
+}
+
+func TestRenderOperation_UsesCustomEnvelopeChecks(t *testing.T) {
+	op := Operation{
+		Name:      "getUser",
+		Method:    "get",
+		Path:      "/users",
+		Return:    ReturnInfo{Type: "User"},
+		ErrorText: "请求失败",
+		Envelope:  Envelope{DataPath: "result", SuccessField: "code", SuccessValue: float64(0), MessageField: "msg"},
+	}
+
+	content := RenderOperation(op)
+	if !strings.Contains(content, "if (res.data.code === 0 && res.data.result !== undefined) {") {
+		t.Fatalf("unexpected success check:\n%s", content)
+	}
+	if !strings.Contains(content, "new ApiError(res.status, '', res.data, res.data?.msg ?? '请求失败')") {
+		t.Fatalf("unexpected error message access:\n%s", content)
+	}
+}
+
+func TestRenderOperation_UnwrappedSkipsEnvelopeChecks(t *testing.T) {
+	op := Operation{
+		Name:     "health",
+		Method:   "get",
+		Path:     "/health",
+		Return:   ReturnInfo{Type: "HealthStatus"},
+		Envelope: Envelope{Unwrapped: true},
+	}
+
+	content := RenderOperation(op)
+	if !strings.Contains(content, "const res = await request\n    .get<HealthStatus>('/health')\n    .catch((error) => Promise.reject(toApiError(error, '')));\n  return res.data;\n") {
+		t.Fatalf("unexpected unwrapped body:\n%s", content)
+	}
+	if strings.Contains(content, "ApiResult") || strings.Contains(content, "success") {
+		t.Fatalf("unwrapped operation should not reference the envelope:\n%s", content)
+	}
+
+	header := RenderAPIFile([]Operation{op}, nil)
+	if strings.Contains(header, "ApiResult") {
+		t.Fatalf("unwrapped-only file should not import ApiResult:\n%s", header)
+	}
+}
+
+func TestEnvelopeForPath_MarksUnwrappedPrefixes(t *testing.T) {
+	envelope := Envelope{UnwrappedPaths: []string{"/health"}}
+	if !envelope.forPath("/health/live").Unwrapped {
+		t.Fatal("expected /health/live to be unwrapped")
+	}
+	if envelope.forPath("/api/v1/users").Unwrapped {
+		t.Fatal("expected /api/v1/users to keep the envelope")
+	}
+}
+
+func TestRenderRootIndexFile_RendersCustomEnvelopeFields(t *testing.T) {
+	content := renderRootIndexFile(Envelope{DataPath: "result", SuccessField: "code", SuccessValue: float64(0), MessageField: "msg"}, nil)
+	for _, want := range []string{"  code: number;\n", "  result?: T;\n", "  msg: string;\n"} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("root index missing %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "success: boolean;") {
+		t.Fatalf("custom envelope should not keep the fiberx success field:\n%s", content)
+	}
+}
@@ -0,0 +1,250 @@
+package generator
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const unknownErrorBodyType = "unknown"
+
+// errorRangePattern matches the status range keys OpenAPI allows besides status codes.
+var errorRangePattern = regexp.MustCompile(`^[1345]XX$`)
+
+// extractErrorResponses collects the non-2xx responses of an operation: status codes in order,
+// then ranges such as 4XX, then default. default is skipped when it is the only success response.
+func extractErrorResponses(op *openapi3.Operation) []RawErrorResponse {
+	if op == nil || op.Responses == nil {
+		return nil
+	}
+
+	hasSuccess := false
+	var result []RawErrorResponse
+	for key, responseRef := range op.Responses.Map() {
+		status, err := strconv.Atoi(key)
+		switch {
+		case err == nil && status >= 200 && status < 300, strings.EqualFold(key, "2XX"):
+			hasSuccess = true
+			continue
+		case err == nil:
+		case errorRangePattern.MatchString(strings.ToUpper(key)):
+			key = strings.ToUpper(key)
+		case key == "default":
+		default:
+			continue
+		}
+		if responseRef == nil || responseRef.Value == nil {
+			continue
+		}
+		description := ""
+		if responseRef.Value.Description != nil {
+			description = strings.TrimSpace(*responseRef.Value.Description)
+		}
+		_, schemaRef := pickContentSchema(responseRef.Value.Content)
+		result = append(result, RawErrorResponse{
+			Key:         key,
+			Status:      status,
+			Description: description,
+			Schema:      schemaRef,
+		})
+	}
+	if !hasSuccess {
+		filtered := result[:0]
+		for _, response := range result {
+			if response.Key != "default" {
+				filtered = append(filtered, response)
+			}
+		}
+		result = filtered
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := errorKeyRank(result[i]), errorKeyRank(result[j])
+		if a != b {
+			return a < b
+		}
+		if result[i].Status != result[j].Status {
+			return result[i].Status < result[j].Status
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+func errorKeyRank(response RawErrorResponse) int {
+	switch {
+	case response.Status != 0:
+		return 0
+	case response.Key != "default":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// successStatus returns the status code of the response pickResponse selects, or 200.
+func successStatus(op *openapi3.Operation) int {
+	if op == nil || op.Responses == nil {
+		return 200
+	}
+	picked := pickResponse(op.Responses)
+	for key, responseRef := range op.Responses.Map() {
+		if responseRef != picked {
+			continue
+		}
+		if status, err := strconv.Atoi(key); err == nil && status >= 200 && status < 300 {
+			return status
+		}
+	}
+	return 200
+}
+
+// resolveErrorTypes maps the documented non-2xx responses of an operation to typed ApiError variants.
+// Error bodies shaped like the response envelope reuse ApiResult instead of emitting a model per group.
+// Download operations request a Blob, so their error bodies arrive as a Blob too.
+func resolveErrorTypes(opName string, responses []RawErrorResponse, registry *TypeRegistry, envelope Envelope, download bool) ([]ErrorInfo, []string, error) {
+	var (
+		errorsInfo []ErrorInfo
+		usedTypes  []string
+	)
+	for _, response := range responses {
+		typeName := unknownErrorBodyType
+		switch {
+		case download:
+			typeName = "Blob"
+		case isEmptySchema(response.Schema):
+		case !envelope.Unwrapped && isEnvelopeSchema(response.Schema, registry, envelope):
+			typeName = "ApiResult"
+		case response.Schema.Ref != "":
+			refName, err := registry.directedRef(response.Schema.Ref)
+			if err != nil {
+				return nil, nil, err
+			}
+			typeName = refName
+			usedTypes = append(usedTypes, refName)
+		default:
+			typeName = registry.RegisterInline(opName+"Error"+upperFirst(response.Key), response.Schema, response.Description)
+			usedTypes = append(usedTypes, typeName)
+		}
+		errorsInfo = append(errorsInfo, ErrorInfo{
+			Key:         response.Key,
+			Status:      response.Status,
+			TypeName:    typeName,
+			Description: response.Description,
+		})
+	}
+	return errorsInfo, usedTypes, nil
+}
+
+// isEnvelopeSchema reports whether a schema is the response envelope, recognised by its success field.
+func isEnvelopeSchema(schemaRef *openapi3.SchemaRef, registry *TypeRegistry, envelope Envelope) bool {
+	return lookupEnvelopeProperty(schemaRef, envelope.successField(), registry) != nil
+}
+
+// renderErrorUnion renders the union of ApiError variants an operation may reject with: one per
+// documented error response, plus envelope failures carrying the success status and network
+// errors carrying status 0. Ranges (4XX) and default responses cannot be narrowed on status, so
+// their variants use number.
+func renderErrorUnion(op Operation) string {
+	if op.ErrorType == "" || len(op.Errors) == 0 {
+		return ""
+	}
+	type variant struct {
+		body, status, label, description string
+	}
+	variants := make([]variant, 0, len(op.Errors)+2)
+	for _, info := range op.Errors {
+		description := info.Description
+		if description == "" {
+			description = "错误响应"
+		}
+		variants = append(variants, variant{info.TypeName, info.statusType(), info.label(), description})
+	}
+	if !op.Return.IsDownload && !op.Envelope.Unwrapped {
+		status := op.SuccessStatus
+		if status == 0 {
+			status = 200
+		}
+		variants = append(variants, variant{"ApiResult", strconv.Itoa(status), strconv.Itoa(status), "业务失败（响应包装表示失败）"})
+	}
+	variants = append(variants, variant{"unknown", "0", "0", "网络错误，未收到响应"})
+
+	members := make([]string, 0, len(variants))
+	var b strings.Builder
+	b.WriteString("/**\n")
+	b.WriteString(" * " + op.Name + " 的错误响应\n")
+	for _, item := range variants {
+		members = append(members, "ApiError<"+item.body+", "+item.status+">")
+		b.WriteString(" * - " + item.label + ": " + escapeJSDoc(item.description) + "\n")
+	}
+	b.WriteString(" */\n")
+	b.WriteString("export type " + op.ErrorType + " = " + strings.Join(members, " | ") + ";\n\n")
+	return b.String()
+}
+
+// statusType is the TypeScript type of ApiError.status for the response.
+func (e ErrorInfo) statusType() string {
+	if e.Status != 0 {
+		return strconv.Itoa(e.Status)
+	}
+	return "number"
+}
+
+func (e ErrorInfo) label() string {
+	if e.Key != "" {
+		return e.Key
+	}
+	return strconv.Itoa(e.Status)
+}
+
+// renderApiErrorHelpers renders the ApiError class and the helpers that convert transport failures into it.
+func renderApiErrorHelpers(envelope Envelope) string {
+	var b strings.Builder
+	b.WriteString(`/**
+ * 接口错误，status 为 HTTP 状态码（网络错误时为 0）
+ */
+export class ApiError<TBody = unknown, TStatus extends number = number> extends Error {
+  /** HTTP 状态码 */
+  readonly status: TStatus;
+  /** 错误原因 */
+  readonly reason: string;
+  /** 错误响应体 */
+  readonly body: TBody;
+
+  constructor(status: TStatus, reason: string, body: TBody, message: string) {
+    super(message);
+    this.name = 'ApiError';
+    this.status = status;
+    this.reason = reason;
+    this.body = body;
+  }
+}
+
+/**
+ * 判断是否为接口错误，可传入接口的错误联合类型后按 status 收窄
+ */
+export function isApiError<E extends ApiError = ApiError>(error: unknown): error is E {
+  return error instanceof ApiError;
+}
+
+/**
+ * 将请求异常转换为 ApiError
+ */
+export function toApiError(error: unknown, fallbackMessage: string): ApiError {
+  if (error instanceof ApiError) {
+    return error;
+  }
+  const response = (error as { response?: { status?: number; data?: any } } | null)?.response;
+  const body = response?.data;
+  const message = `)
+	b.WriteString(envelope.messageExpr("body"))
+	b.WriteString(` ?? (error instanceof Error ? error.message : fallbackMessage);
+  return new ApiError(response?.status ?? 0, `)
+	b.WriteString(envelope.reasonExpr("body"))
+	b.WriteString(`, body, message);
+}
+`)
+	return b.String()
+}
@@ -0,0 +1,105 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestExtractErrorResponses_SortsNonSuccessStatuses(t *testing.T) {
+	responses := openapi3.NewResponses()
+	for _, status := range []int{200, 500, 400, 429} {
+		description := "status"
+		responses.Set(strconv.Itoa(status), &openapi3.ResponseRef{Value: &openapi3.Response{Description: &description}})
+	}
+	responses.Set("default", &openapi3.ResponseRef{Value: openapi3.NewResponse()})
+
+	got := extractErrorResponses(&openapi3.Operation{Responses: responses})
+	var keys []string
+	for _, response := range got {
+		keys = append(keys, response.Key)
+	}
+	if strings.Join(keys, ",") != "400,429,500,default" {
+		t.Fatalf("unexpected error responses: %v", keys)
+	}
+}
+
+func TestExtractErrorResponses_KeepsRangesAndDefault(t *testing.T) {
+	responses := openapi3.NewResponses()
+	for _, key := range []string{"201", "default", "5xx", "4XX", "404", "2XX"} {
+		responses.Set(key, &openapi3.ResponseRef{Value: openapi3.NewResponse()})
+	}
+
+	got := extractErrorResponses(&openapi3.Operation{Responses: responses})
+	var keys []string
+	for _, response := range got {
+		keys = append(keys, response.Key)
+	}
+	if strings.Join(keys, ",") != "404,4XX,5XX,default" {
+		t.Fatalf("unexpected error responses: %v", keys)
+	}
+	if got[1].Status != 0 || got[3].Status != 0 {
+		t.Fatalf("range and default responses should not carry a status: %+v", got)
+	}
+}
+
+func TestResolveErrorTypes_ReusesApiResultForEnvelopeBodies(t *testing.T) {
+	envelopeSchema := openapi3.NewObjectSchema().
+		WithProperty("success", openapi3.NewBoolSchema()).
+		WithProperty("data", openapi3.NewObjectSchema())
+	problemSchema := openapi3.NewObjectSchema().WithProperty("detail", openapi3.NewStringSchema())
+	registry := NewTypeRegistry(&openapi3.T{})
+
+	infos, used, err := resolveErrorTypes("createRole", []RawErrorResponse{
+		{Key: "400", Status: 400, Schema: envelopeSchema.NewRef()},
+		{Key: "422", Status: 422, Schema: problemSchema.NewRef()},
+		{Key: "500", Status: 500},
+	}, registry, Envelope{}, false)
+	if err != nil {
+		t.Fatalf("resolveErrorTypes returned error: %v", err)
+	}
+
+	want := []string{"ApiResult", "CreateRoleError422", "unknown"}
+	for idx, info := range infos {
+		if info.TypeName != want[idx] {
+			t.Fatalf("unexpected error body type for %d: got %s want %s", info.Status, info.TypeName, want[idx])
+		}
+	}
+	if len(used) != 1 || used[0] != "CreateRoleError422" {
+		t.Fatalf("unexpected used types: %v", used)
+	}
+}
+
+func TestRenderOperation_RendersErrorUnionAndApiErrorRejection(t *testing.T) {
+	op := Operation{
+		Name:      "createRole",
+		Method:    "post",
+		Path:      "/api/v1/roles",
+		Return:    ReturnInfo{Type: "void", IsVoid: true},
+		ErrorText: "创建角色失败",
+		Errors: []ErrorInfo{
+			{Status: 400, TypeName: "ApiResult", Description: "请求参数错误"},
+			{Key: "5XX", TypeName: "ApiResult", Description: "服务端错误 */"},
+		},
+		ErrorType: "CreateRoleError",
+	}
+
+	content := RenderOperation(op)
+	if !strings.Contains(content, "export type CreateRoleError = ApiError<ApiResult, 400> | ApiError<ApiResult, number> | ApiError<ApiResult, 200> | ApiError<unknown, 0>;\n") {
+		t.Fatalf("missing error union:\n%s", content)
+	}
+	if !strings.Contains(content, " * - 5XX: 服务端错误 *\\/\n") {
+		t.Fatalf("range description should be listed and escaped:\n%s", content)
+	}
+	if !strings.Contains(content, " * @throws {CreateRoleError}\n") {
+		t.Fatalf("missing @throws tag:\n%s", content)
+	}
+	if !strings.Contains(content, ".catch((error) => Promise.reject(toApiError(error, '创建角色失败')));") {
+		t.Fatalf("transport errors should be mapped to ApiError:\n%s", content)
+	}
+	if !strings.Contains(content, "new ApiError(res.status, res.data?.reason ?? '', res.data, res.data?.message ?? '创建角色失败')") {
+		t.Fatalf("envelope failures should reject with ApiError:\n%s", content)
+	}
+}
@@ -1,13 +1,17 @@
 package generator
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/gopkg-dev/swagger-ts-gen/internal/diff"
+	"github.com/gopkg-dev/swagger-ts-gen/internal/surface"
 )
 
 type Options struct {
@@ -18,16 +22,49 @@ type Options struct {
 	RequiredByOmitEmpty    bool
 	CleanOutput            bool
 	DedupeCrossGroupModels bool
+	Envelope               Envelope
+	Pagination             Pagination
+	Grouping               Grouping
+	// Check renders in memory and reports differences with OutputDir instead of writing.
+	Check bool
+	// Strict fails generation with a *StrictError when any schema falls back to any.
+	Strict bool
+	// Manifest writes ManifestFile and, from the second run on, a ChangelogFile section
+	// whenever the emitted API changed.
+	Manifest bool
+	// EnumStyle is EnumStyleConst (default), EnumStyleEnum or EnumStyleUnion.
+	EnumStyle string
+	// TypeGuards emits an isXxx type guard per variant of named discriminated unions.
+	TypeGuards bool
+	// Scalars maps string and number formats such as int64 or date-time to TypeScript types,
+	// overriding DefaultScalarFormats.
+	Scalars map[string]string
+	// TypeOverrides replaces the generated type of schemas, keyed by component name or by JSON
+	// pointer such as /components/schemas/Order/properties/meta.
+	TypeOverrides map[string]TypeOverride
 }
 
 type Report struct {
 	Groups     int
 	Operations int
 	Types      int
+	// Written, Unchanged and Deleted count files touched by a regular run.
+	Written   int
+	Unchanged int
+	Deleted   int
+	// Changes lists the files added, changed or removed by the run; in check mode, the files
+	// that differ from disk and would be touched.
+	Changes []FileChange
+	// Warnings lists schema constructs that could not be expressed in TypeScript.
+	Warnings []string
+	// Fallbacks lists the schemas emitted as any, e.g. unresolved refs and untyped schemas.
+	Fallbacks []Fallback
+	// APIChanges lists the differences from the previous manifest when Options.Manifest is set.
+	APIChanges []diff.Change
 }
 
 type Generator struct {
-	spec                   *openapi3.T
+	sources                []Source
 	outputDir              string
 	logf                   func(string, ...any)
 	goSourceDir            string
@@ -36,31 +73,52 @@ type Generator struct {
 	optionalFieldsByType   map[string][]GoStructOptionality
 	cleanOutput            bool
 	dedupeCrossGroupModels bool
+	envelope               Envelope
+	pagination             Pagination
+	grouping               Grouping
+	check                  bool
+	strict                 bool
+	manifest               bool
+	enumStyle              string
+	typeGuards             bool
+	scalars                map[string]string
+	typeOverrides          map[string]TypeOverride
+	now                    func() time.Time
 }
 
 type renderedTypeEntry struct {
 	Def     *TypeDef
 	Content string
 	Deps    []string
+	// Values are the runtime names the entry exports besides its type, e.g. enum objects and type guards.
+	Values []string
+	// Imports are the types the entry imports from '@/api' or x-ts-import modules.
+	Imports []TypeImport
 }
 
 type groupGenerationContext struct {
-	rawOps         []RawOperation
-	typedOps       []Operation
-	apiImports     []string
-	usesPageResult bool
-	registry       *TypeRegistry
-	typeEntries    map[string]renderedTypeEntry
-	typeOrder      []string
+	rawOps      []RawOperation
+	typedOps    []Operation
+	apiImports  []string
+	registry    *TypeRegistry
+	typeEntries map[string]renderedTypeEntry
+	typeOrder   []string
 }
 
 func New(spec *openapi3.T, opts Options) *Generator {
+	return NewFromSources([]Source{{Spec: spec}}, opts)
+}
+
+// NewFromSources merges several specs into one output tree with a single root index.ts.
+// Every group belongs to exactly one source, so same-named components of different specs
+// never share a TypeRegistry.
+func NewFromSources(sources []Source, opts Options) *Generator {
 	output := strings.TrimSpace(opts.OutputDir)
 	if output == "" {
 		output = "api"
 	}
 	return &Generator{
-		spec:                   spec,
+		sources:                sources,
 		outputDir:              output,
 		logf:                   opts.Logf,
 		goSourceDir:            strings.TrimSpace(opts.GoSourceDir),
@@ -68,35 +126,107 @@ func New(spec *openapi3.T, opts Options) *Generator {
 		requiredByOmitEmpty:    opts.RequiredByOmitEmpty,
 		cleanOutput:            opts.CleanOutput,
 		dedupeCrossGroupModels: opts.DedupeCrossGroupModels,
+		envelope:               opts.Envelope,
+		pagination:             opts.Pagination,
+		grouping:               opts.Grouping,
+		check:                  opts.Check,
+		strict:                 opts.Strict,
+		manifest:               opts.Manifest,
+		enumStyle:              strings.TrimSpace(opts.EnumStyle),
+		typeGuards:             opts.TypeGuards,
+		scalars:                resolveScalarFormats(opts.Scalars),
+		typeOverrides:          resolveTypeOverrides(opts.TypeOverrides),
+		now:                    time.Now,
 	}
 }
 
 func (g *Generator) Generate() (*Report, error) {
-	if g.spec == nil {
-		return nil, fmt.Errorf("spec is nil")
+	return g.GenerateContext(context.Background())
+}
+
+// GenerateContext is Generate with a context that is checked between groups and before any file
+// is written, so a cancelled run leaves the output dir untouched.
+func (g *Generator) GenerateContext(ctx context.Context) (*Report, error) {
+	output, report, groupNames, err := g.render(ctx)
+	if err != nil {
+		return nil, err
 	}
-	if g.requiredByOmitEmpty {
-		if g.goSourceDir == "" {
-			return nil, fmt.Errorf("go source dir is required when required-by-omitempty is enabled")
+
+	if g.check {
+		if report.Changes, err = g.checkOutput(output, groupNames); err != nil {
+			return nil, err
 		}
-		optionalFieldsByType, err := ParseGoOptionalFieldsByType(g.goSourceDir, g.goSourceIncludeDirs)
+		return report, nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(g.outputDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create output dir failed: %w", err)
+	}
+	var deleted []string
+	if g.cleanOutput {
+		deleted, err = pruneStaleOutput(g.outputDir, output, groupNames, false)
 		if err != nil {
-			return nil, fmt.Errorf("load go optional fields failed: %w", err)
+			return nil, err
 		}
-		g.optionalFieldsByType = optionalFieldsByType
-		if g.logf != nil {
-			g.logf("go optional fields loaded: %d struct(s), include=%s", len(optionalFieldsByType), strings.Join(g.goSourceIncludeDirs, ","))
+	}
+	written, unchanged, err := output.write(g.outputDir)
+	if err != nil {
+		return nil, err
+	}
+	report.Written = len(written)
+	report.Unchanged = unchanged
+	report.Deleted = len(deleted)
+	report.Changes = mergeChanges(written, deleted)
+
+	return report, nil
+}
+
+// checkOutput compares the rendered output with the output dir without touching it. The files a
+// regular run would prune are reported as removed.
+func (g *Generator) checkOutput(output *renderedOutput, groupNames []string) ([]FileChange, error) {
+	var staleFiles []string
+	if g.cleanOutput {
+		var err error
+		staleFiles, err = pruneStaleOutput(g.outputDir, output, groupNames, true)
+		if err != nil {
+			return nil, err
 		}
 	}
+	return output.diff(g.outputDir, staleFiles)
+}
 
-	ops, err := ExtractOperations(g.spec)
+// Render generates every file in memory, keyed by its path relative to the output dir with
+// forward slashes. Nothing is written; the output dir is only read for the previous manifest and,
+// with Options.Check, to fill Report.Changes as Generate does.
+func (g *Generator) Render(ctx context.Context) (map[string]string, *Report, error) {
+	output, report, groupNames, err := g.render(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if g.check {
+		if report.Changes, err = g.checkOutput(output, groupNames); err != nil {
+			return nil, nil, err
+		}
 	}
+	files := make(map[string]string, len(output.Files))
+	for _, file := range output.Files {
+		files[file.Path] = file.Content
+	}
+	return files, report, nil
+}
 
-	groups := map[string][]RawOperation{}
-	for _, op := range ops {
-		groups[op.Group] = append(groups[op.Group], op)
+// render builds the output of every group and the report shared by Generate and Render.
+func (g *Generator) render(ctx context.Context) (*renderedOutput, *Report, []string, error) {
+	if err := g.prepare(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	ops, groups, groupSources, err := g.groupOperations()
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
 	groupNames := make([]string, 0, len(groups))
@@ -105,39 +235,34 @@ func (g *Generator) Generate() (*Report, error) {
 	}
 	sort.Strings(groupNames)
 
-	if err := os.MkdirAll(g.outputDir, 0o755); err != nil {
-		return nil, fmt.Errorf("create output dir failed: %w", err)
-	}
-	if g.cleanOutput {
-		if err := pruneStaleGroupDirs(g.outputDir, groupNames); err != nil {
-			return nil, err
-		}
-	}
-	if err := os.WriteFile(filepath.Join(g.outputDir, "index.ts"), []byte(renderRootIndexFile()), 0o644); err != nil {
-		return nil, fmt.Errorf("write root index failed: %w", err)
-	}
-
 	report := &Report{}
 	groupContexts := map[string]*groupGenerationContext{}
+	api := &surface.Surface{Functions: []surface.Function{}, Types: []surface.Type{}}
 
 	for _, groupName := range groupNames {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, nil, err
+		}
 		rawOps := groups[groupName]
-		typedOps, apiImports, usesPageResult, registry, err := g.buildGroupOperations(rawOps)
+		source := g.sources[groupSources[groupName]]
+		typedOps, apiImports, registry, err := g.buildGroupOperations(source, rawOps)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, err
 		}
 
 		expandRegistryReferences(registry)
 		typeDefs := registry.Types()
 		typeEntries, typeOrder := renderTypeEntries(typeDefs, registry)
+		if g.manifest {
+			describeGroup(api, groupName, typedOps, registry)
+		}
 		groupContexts[groupName] = &groupGenerationContext{
-			rawOps:         rawOps,
-			typedOps:       typedOps,
-			apiImports:     apiImports,
-			usesPageResult: usesPageResult,
-			registry:       registry,
-			typeEntries:    typeEntries,
-			typeOrder:      typeOrder,
+			rawOps:      rawOps,
+			typedOps:    typedOps,
+			apiImports:  apiImports,
+			registry:    registry,
+			typeEntries: typeEntries,
+			typeOrder:   typeOrder,
 		}
 		report.Types += len(typeDefs)
 
@@ -145,701 +270,179 @@ func (g *Generator) Generate() (*Report, error) {
 		report.Operations += len(rawOps)
 	}
 
+	output := &renderedOutput{}
+	rootIndex := renderRootIndexFile(g.envelope, g.pagination.activeProfiles())
+	if hasDownloadOperations(ops) {
+		rootIndex += "\n" + downloadHelpers
+	}
+	rootIndex += renderScalarHelpers(usedRootImports(groupContexts))
+	output.addFile("index.ts", rootIndex)
+
 	modelRedirectsByGroup := map[string]map[string]string{}
 	if g.dedupeCrossGroupModels {
 		modelRedirectsByGroup = buildModelRedirectPlan(groupNames, groupContexts)
 	}
 
 	for _, groupName := range groupNames {
-		context := groupContexts[groupName]
-		if context == nil {
+		groupCtx := groupContexts[groupName]
+		if groupCtx == nil {
 			continue
 		}
 
-		groupDir := filepath.Join(g.outputDir, groupName)
-		modelDir := filepath.Join(groupDir, "model")
+		output.addDir(groupName + "/model")
 
-		if err := os.MkdirAll(modelDir, 0o755); err != nil {
-			return nil, fmt.Errorf("create model dir failed: %w", err)
-		}
-
-		modelContent, modelLines := renderGroupModelBundle(groupName, context, modelRedirectsByGroup[groupName])
+		modelContent, modelLines := renderGroupModelBundle(groupName, groupCtx, modelRedirectsByGroup[groupName])
 		if modelLines > 0 {
-			if err := os.WriteFile(filepath.Join(modelDir, "index.ts"), []byte(modelContent), 0o644); err != nil {
-				return nil, fmt.Errorf("write model bundle failed: %w", err)
-			}
+			output.addFile(groupName+"/model/index.ts", modelContent)
 		}
 
-		apiFiles := SplitAndRenderAPI(context.typedOps, context.apiImports, context.usesPageResult)
+		apiFiles := SplitAndRenderAPI(groupCtx.typedOps, groupCtx.apiImports)
 		for idx, content := range apiFiles {
 			name := "index.ts"
 			if len(apiFiles) > 1 {
 				name = fmt.Sprintf("api_%d.ts", idx+1)
 			}
-			if err := os.WriteFile(filepath.Join(groupDir, name), []byte(content), 0o644); err != nil {
-				return nil, fmt.Errorf("write api file failed: %w", err)
-			}
+			output.addFile(groupName+"/"+name, content)
 		}
 
 		if len(apiFiles) > 1 {
-			indexContent := renderAPIIndex(len(apiFiles))
-			if err := os.WriteFile(filepath.Join(groupDir, "index.ts"), []byte(indexContent), 0o644); err != nil {
-				return nil, fmt.Errorf("write api index failed: %w", err)
-			}
-		}
-	}
-
-	return report, nil
-}
-
-func normalizeGoSourceIncludeDirs(includeDirs []string) []string {
-	defaultIncludeDirs := []string{"schema", "fiberx"}
-	if len(includeDirs) == 0 {
-		return defaultIncludeDirs
-	}
-	normalized := make([]string, 0, len(includeDirs))
-	seen := map[string]struct{}{}
-	for _, includeDir := range includeDirs {
-		trimmed := strings.TrimSpace(includeDir)
-		if trimmed == "" {
-			continue
-		}
-		if _, exists := seen[trimmed]; exists {
-			continue
+			output.addFile(groupName+"/index.ts", renderAPIIndex(len(apiFiles)))
 		}
-		seen[trimmed] = struct{}{}
-		normalized = append(normalized, trimmed)
 	}
-	if len(normalized) == 0 {
-		return defaultIncludeDirs
-	}
-	return normalized
-}
 
-func renderTypeEntries(defs []*TypeDef, registry *TypeRegistry) (map[string]renderedTypeEntry, []string) {
-	entries := make(map[string]renderedTypeEntry, len(defs))
-	order := make([]string, 0, len(defs))
-	for _, def := range defs {
-		if def == nil || def.Name == "" {
-			continue
-		}
-		content, deps := RenderType(def, registry)
-		if content == "" {
-			continue
-		}
-		entries[def.Name] = renderedTypeEntry{
-			Def:     def,
-			Content: content,
-			Deps:    deps,
+	if g.manifest {
+		if err := g.addManifest(output, api, report); err != nil {
+			return nil, nil, nil, err
 		}
-		order = append(order, def.Name)
 	}
-	return entries, order
-}
-
-func buildModelRedirectPlan(groupNames []string, contexts map[string]*groupGenerationContext) map[string]map[string]string {
-	signatureToGroups := map[string][]string{}
-	signatureToTypeName := map[string]string{}
 
+	warnings := append(unusedTypeOverrides(g.typeOverrides, g.sources), invalidTypeExtensions(g.sources)...)
+	fallbackSeen := map[Fallback]struct{}{}
 	for _, groupName := range groupNames {
-		context := contexts[groupName]
-		if context == nil {
-			continue
-		}
-		for typeName, entry := range context.typeEntries {
-			signature := typeName + "\x1f" + entry.Content
-			signatureToGroups[signature] = append(signatureToGroups[signature], groupName)
-			signatureToTypeName[signature] = typeName
-		}
-	}
-
-	redirectsByGroup := map[string]map[string]string{}
-	for signature, groups := range signatureToGroups {
-		if len(groups) < 2 {
-			continue
-		}
-		sortedGroups := uniqueStrings(groups)
-		if len(sortedGroups) < 2 {
-			continue
-		}
-		canonicalGroup := sortedGroups[0]
-		typeName := signatureToTypeName[signature]
-		for _, groupName := range sortedGroups[1:] {
-			if redirectsByGroup[groupName] == nil {
-				redirectsByGroup[groupName] = map[string]string{}
-			}
-			redirectsByGroup[groupName][typeName] = canonicalGroup
-		}
-	}
-
-	return redirectsByGroup
-}
-
-func renderGroupModelBundle(groupName string, context *groupGenerationContext, redirects map[string]string) (string, int) {
-	if context == nil {
-		return "", 0
-	}
-	if len(context.typeOrder) == 0 {
-		return "", 0
-	}
-	if redirects == nil {
-		redirects = map[string]string{}
-	}
-
-	localDefs := make([]*TypeDef, 0, len(context.typeOrder))
-	localTypeEntryByName := map[string]renderedTypeEntry{}
-	for _, typeName := range context.typeOrder {
-		if _, redirected := redirects[typeName]; redirected {
-			continue
-		}
-		entry, exists := context.typeEntries[typeName]
-		if !exists || entry.Def == nil {
-			continue
-		}
-		localDefs = append(localDefs, entry.Def)
-		localTypeEntryByName[typeName] = entry
-	}
-
-	neededRedirectImports := map[string]map[string]struct{}{}
-	for _, entry := range localTypeEntryByName {
-		for _, dep := range entry.Deps {
-			sourceGroup, redirected := redirects[dep]
-			if !redirected {
+		registry := groupContexts[groupName].registry
+		warnings = append(warnings, registry.Warnings()...)
+		for _, fallback := range registry.Fallbacks() {
+			if _, ok := fallbackSeen[fallback]; ok {
 				continue
 			}
-			if sourceGroup == "" || sourceGroup == groupName {
-				continue
-			}
-			if neededRedirectImports[sourceGroup] == nil {
-				neededRedirectImports[sourceGroup] = map[string]struct{}{}
-			}
-			neededRedirectImports[sourceGroup][dep] = struct{}{}
-		}
-	}
-
-	redirectedExports := map[string]map[string]struct{}{}
-	for typeName, sourceGroup := range redirects {
-		if sourceGroup == "" || sourceGroup == groupName {
-			continue
-		}
-		if redirectedExports[sourceGroup] == nil {
-			redirectedExports[sourceGroup] = map[string]struct{}{}
-		}
-		redirectedExports[sourceGroup][typeName] = struct{}{}
-	}
-
-	localModelContent, localModelLines := renderModelDefinitions(localDefs, context.registry)
-	includePageParamImport := needsPageParamImport(localDefs)
-	if !includePageParamImport && len(neededRedirectImports) == 0 && len(redirectedExports) == 0 {
-		if localModelLines == 0 {
-			return "", 0
-		}
-		return localModelContent, localModelLines
-	}
-
-	var b strings.Builder
-	if includePageParamImport {
-		b.WriteString("import type { PageParam } from '@/api';\n")
-	}
-
-	importSources := make([]string, 0, len(neededRedirectImports))
-	for sourceGroup := range neededRedirectImports {
-		importSources = append(importSources, sourceGroup)
-	}
-	sort.Strings(importSources)
-	for _, sourceGroup := range importSources {
-		importedNames := mapKeysSorted(neededRedirectImports[sourceGroup])
-		if len(importedNames) == 0 {
-			continue
+			fallbackSeen[fallback] = struct{}{}
+			report.Fallbacks = append(report.Fallbacks, fallback)
 		}
-		b.WriteString("import type { " + strings.Join(importedNames, ", ") + " } from '../../" + sourceGroup + "/model';\n")
 	}
-
-	exportSources := make([]string, 0, len(redirectedExports))
-	for sourceGroup := range redirectedExports {
-		exportSources = append(exportSources, sourceGroup)
-	}
-	sort.Strings(exportSources)
-	if len(exportSources) > 0 && b.Len() > 0 {
-		b.WriteString("\n")
-	}
-	for _, sourceGroup := range exportSources {
-		exportedNames := mapKeysSorted(redirectedExports[sourceGroup])
-		if len(exportedNames) == 0 {
-			continue
-		}
-		b.WriteString("export type { " + strings.Join(exportedNames, ", ") + " } from '../../" + sourceGroup + "/model';\n")
+	report.Warnings = uniqueStrings(warnings)
+	sortFallbacks(report.Fallbacks)
+	if g.strict && len(report.Fallbacks) > 0 {
+		return nil, nil, nil, &StrictError{Fallbacks: report.Fallbacks}
 	}
+	return output, report, groupNames, nil
+}
 
-	if localModelLines > 0 {
-		if b.Len() > 0 {
-			b.WriteString("\n")
-		}
-		b.WriteString(localModelContent)
+// prepare validates the options and loads the Go optional-field hints used by type rendering.
+func (g *Generator) prepare() error {
+	if err := validateSources(g.sources); err != nil {
+		return err
 	}
-
-	finalContent := b.String()
-	if finalContent == "" {
-		return "", 0
+	if err := g.pagination.validate(); err != nil {
+		return err
 	}
-	return finalContent, countLines(finalContent)
-}
-
-func mapKeysSorted(values map[string]struct{}) []string {
-	if len(values) == 0 {
-		return nil
+	if err := g.grouping.validate(); err != nil {
+		return err
 	}
-	keys := make([]string, 0, len(values))
-	for key := range values {
-		keys = append(keys, key)
+	if err := validateEnumStyle(g.enumStyle); err != nil {
+		return err
 	}
-	sort.Strings(keys)
-	return keys
-}
-
-func pruneStaleGroupDirs(outputDir string, groupNames []string) error {
-	entries, err := os.ReadDir(outputDir)
-	if err != nil {
-		return fmt.Errorf("read output dir failed: %w", err)
+	if err := validateScalarFormats(g.scalars); err != nil {
+		return err
 	}
-
-	activeGroups := make(map[string]struct{}, len(groupNames))
-	for _, groupName := range groupNames {
-		activeGroups[groupName] = struct{}{}
+	if err := validateTypeOverrides(g.typeOverrides); err != nil {
+		return err
 	}
-
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
-		}
-		name := entry.Name()
-		if _, isActive := activeGroups[name]; isActive {
-			continue
+	if g.requiredByOmitEmpty {
+		if g.goSourceDir == "" {
+			return fmt.Errorf("go source dir is required when required-by-omitempty is enabled")
 		}
-
-		groupDirPath := filepath.Join(outputDir, name)
-		if !looksLikeGeneratedGroupDir(groupDirPath) {
-			continue
+		optionalFieldsByType, err := ParseGoOptionalFieldsByType(g.goSourceDir, g.goSourceIncludeDirs)
+		if err != nil {
+			return fmt.Errorf("load go optional fields failed: %w", err)
 		}
-		if err := os.RemoveAll(groupDirPath); err != nil {
-			return fmt.Errorf("remove stale group dir %s failed: %w", groupDirPath, err)
+		g.optionalFieldsByType = optionalFieldsByType
+		if g.logf != nil {
+			g.logf("go optional fields loaded: %d struct(s), include=%s", len(optionalFieldsByType), strings.Join(g.goSourceIncludeDirs, ","))
 		}
 	}
-
 	return nil
 }
 
-func looksLikeGeneratedGroupDir(groupDirPath string) bool {
-	if groupDirPath == "" {
-		return false
-	}
-	info, err := os.Stat(filepath.Join(groupDirPath, "index.ts"))
-	if err == nil && !info.IsDir() {
-		return true
-	}
-	modelInfo, modelErr := os.Stat(filepath.Join(groupDirPath, "model", "index.ts"))
-	if modelErr == nil && !modelInfo.IsDir() {
-		return true
-	}
-	return false
-}
-
-func renderTypeFile(content string, deps []string) string {
-	if len(deps) == 0 {
-		return content
-	}
-	var b strings.Builder
-	b.WriteString("import type { ")
-	b.WriteString(strings.Join(deps, ", "))
-	b.WriteString(" } from './index';\n\n")
-	b.WriteString(content)
-	return b.String()
-}
-
-func renderAPIIndex(parts int) string {
-	var b strings.Builder
-	for i := 1; i <= parts; i++ {
-		b.WriteString(fmt.Sprintf("export * from './api_%d';\n", i))
-	}
-	return b.String()
-}
-
-func (g *Generator) buildGroupOperations(rawOps []RawOperation) ([]Operation, []string, bool, *TypeRegistry, error) {
-	registry := NewTypeRegistry(g.spec)
-	registry.SetOptionalFieldsByType(g.optionalFieldsByType)
-	usedTypes := map[string]struct{}{}
-	usesPageResult := false
-
-	ops := make([]Operation, 0, len(rawOps))
-	for _, raw := range rawOps {
-		op := Operation{
-			Name:    ensureUniqueOperationName(raw.Name, ops),
-			Summary: raw.Summary,
-			Method:  raw.Method,
-			Path:    raw.Path,
-			Group:   raw.Group,
-		}
-
-		op.PathParams = buildPathParams(raw.PathParams, registry)
-		for _, param := range op.PathParams {
-			if _, ok := registry.types[param.Type]; ok {
-				usedTypes[param.Type] = struct{}{}
+// groupOperations extracts the operations of every source and assigns their groups. groupSources
+// maps each group to the index of the source that produced it.
+func (g *Generator) groupOperations() ([]RawOperation, map[string][]RawOperation, map[string]int, error) {
+	var ops []RawOperation
+	var untranslatedTags []string
+	groups := map[string][]RawOperation{}
+	groupSources := map[string]int{}
+	for idx, source := range g.sources {
+		sourceOps, err := ExtractOperations(source.Spec)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		tagGroups := tagGroupNames(source.Spec)
+		for _, op := range sourceOps {
+			group, untranslated := g.grouping.groupFor(op, tagGroups)
+			op.Group = source.groupName(group)
+			if untranslated != "" {
+				untranslatedTags = append(untranslatedTags, untranslated)
 			}
-		}
-
-		isPageQuery := hasPageParams(raw.QueryParams)
-		if len(raw.QueryParams) > 0 {
-			querySchema := buildQuerySchema(raw.QueryParams, isPageQuery)
-			var typeName string
-			queryParamName := buildQueryParamTypeName(op.Name, op.Group)
-			if isPageQuery {
-				typeName = registry.RegisterInlineWithExtends(queryParamName, querySchema, "", []string{"PageParam"})
-			} else {
-				typeName = registry.RegisterInline(queryParamName, querySchema, "")
+			if prev, ok := groupSources[op.Group]; ok && prev != idx {
+				return nil, nil, nil, fmt.Errorf("group %q is produced by both %s and %s: set a namespace on one of them",
+					op.Group, g.sources[prev].label(prev), source.label(idx))
 			}
-			op.Query = &QueryInfo{TypeName: typeName, Optional: !hasRequiredParams(raw.QueryParams, isPageQuery)}
-			usedTypes[typeName] = struct{}{}
+			groupSources[op.Group] = idx
+			groups[op.Group] = append(groups[op.Group], op)
 		}
-
-		if raw.Body != nil {
-			typeName := ""
-			if raw.Body.Schema != nil && raw.Body.Schema.Ref != "" {
-				refName, err := registry.RegisterRef(raw.Body.Schema.Ref)
-				if err != nil {
-					return nil, nil, false, nil, err
-				}
-				typeName = refName
-			} else {
-				desc := ""
-				if raw.Body.IsFormData {
-					desc = "FormData"
-				}
-				typeName = registry.RegisterInline(op.Name+"Body", raw.Body.Schema, desc)
-			}
-			op.Body = &BodyInfo{TypeName: typeName, Optional: !raw.Body.Required, IsForm: raw.Body.IsFormData}
-			usedTypes[typeName] = struct{}{}
-		}
-
-		returnInfo, returnTypes := resolveReturnType(op.Name, raw.Response, registry, isPageQuery)
-		op.Return = returnInfo
-		if returnInfo.UsesPageResult {
-			usesPageResult = true
-		}
-		for _, name := range returnTypes {
-			usedTypes[name] = struct{}{}
-		}
-
-		op.ErrorText = buildErrorText(op.Summary)
-
-		if g.logf != nil {
-			g.logf(
-				"operation name=%s method=%s path=%s group=%s page=%t pathParams=%s query=%s queryFields=%s body=%s return=%s",
-				op.Name,
-				strings.ToUpper(op.Method),
-				op.Path,
-				op.Group,
-				isPageQuery,
-				formatRawParamNames(raw.PathParams, false),
-				formatQueryLog(op.Query, isPageQuery),
-				formatRawParamNames(raw.QueryParams, isPageQuery),
-				formatBodyLog(op.Body),
-				op.Return.Type,
-			)
-		}
-
-		ops = append(ops, op)
+		ops = append(ops, sourceOps...)
 	}
-
-	apiImports := make([]string, 0, len(usedTypes))
-	for name := range usedTypes {
-		apiImports = append(apiImports, name)
-	}
-	sort.Strings(apiImports)
-
-	return ops, apiImports, usesPageResult, registry, nil
-}
-
-func ensureUniqueOperationName(name string, ops []Operation) string {
-	used := map[string]struct{}{}
-	for _, op := range ops {
-		used[op.Name] = struct{}{}
-	}
-	if _, ok := used[name]; !ok {
-		return name
-	}
-	idx := 2
-	candidate := fmt.Sprintf("%s%d", name, idx)
-	for {
-		if _, ok := used[candidate]; !ok {
-			return candidate
+	if g.logf != nil {
+		for _, tag := range uniqueStrings(untranslatedTags) {
+			g.logf("tag %q has no ASCII group name, falling back to path groups (set %s on the tag or add a grouping rule)", tag, groupNameExtension)
 		}
-		idx++
-		candidate = fmt.Sprintf("%s%d", name, idx)
 	}
+	return ops, groups, groupSources, nil
 }
 
-func buildQuerySchema(params []RawParam, excludePage bool) *openapi3.SchemaRef {
-	schema := &openapi3.Schema{Type: typesOf("object"), Properties: map[string]*openapi3.SchemaRef{}}
-	for _, param := range params {
-		if excludePage && isPageParamName(param.Name) {
-			continue
-		}
-		propSchema := schemaOrAny(param.Schema)
-		if param.Description != "" && propSchema.Ref == "" {
-			if propSchema.Value == nil {
-				propSchema.Value = &openapi3.Schema{}
-			}
-			propSchema.Value.Description = param.Description
-		}
-		schema.Properties[param.Name] = propSchema
-		if param.Required {
-			schema.Required = append(schema.Required, param.Name)
-		}
+func normalizeGoSourceIncludeDirs(includeDirs []string) []string {
+	defaultIncludeDirs := []string{"schema", "fiberx"}
+	if len(includeDirs) == 0 {
+		return defaultIncludeDirs
 	}
-	return &openapi3.SchemaRef{Value: schema}
-}
-
-func hasRequiredParams(params []RawParam, excludePage bool) bool {
-	for _, param := range params {
-		if excludePage && isPageParamName(param.Name) {
+	normalized := make([]string, 0, len(includeDirs))
+	seen := map[string]struct{}{}
+	for _, includeDir := range includeDirs {
+		trimmed := strings.TrimSpace(includeDir)
+		if trimmed == "" {
 			continue
 		}
-		if param.Required {
-			return true
-		}
-	}
-	return false
-}
-
-func hasPageParams(params []RawParam) bool {
-	hasCurrent := false
-	hasPageSize := false
-	for _, param := range params {
-		switch param.Name {
-		case "current":
-			hasCurrent = true
-		case "pageSize":
-			hasPageSize = true
-		}
-	}
-	return hasCurrent && hasPageSize
-}
-
-func isPageParamName(name string) bool {
-	return name == "current" || name == "pageSize"
-}
-
-func buildQueryParamTypeName(operationName string, groupName string) string {
-	trimmed := strings.TrimSpace(operationName)
-	if trimmed != "" {
-		if strings.HasSuffix(strings.ToLower(trimmed), "param") {
-			return trimmed
-		}
-		return trimmed + "Param"
-	}
-
-	group := strings.TrimSpace(groupName)
-	if group != "" {
-		return group + "Param"
-	}
-	return "Param"
-}
-
-func buildPathParams(params []RawParam, registry *TypeRegistry) []Param {
-	result := make([]Param, 0, len(params))
-	usedNames := map[string]struct{}{}
-	for _, param := range params {
-		typeName := registry.SchemaToType(param.Schema, nil)
-		varName := sanitizeIdentifier(param.Name)
-		if varName == "data" || varName == "params" {
-			varName = varName + "Param"
-		}
-		if _, ok := usedNames[varName]; ok {
-			varName = fmt.Sprintf("%sParam", varName)
-		}
-		usedNames[varName] = struct{}{}
-
-		result = append(result, Param{
-			Name:        param.Name,
-			VarName:     varName,
-			Type:        typeName,
-			Description: param.Description,
-			Required:    param.Required,
-		})
-	}
-
-	return result
-}
-
-func resolveReturnType(opName string, schemaRef *openapi3.SchemaRef, registry *TypeRegistry, isPageQuery bool) (ReturnInfo, []string) {
-	dataSchema := extractDataSchema(schemaRef, registry)
-	if dataSchema == nil || isEmptySchema(dataSchema) {
-		return ReturnInfo{Type: "void", IsVoid: true}, nil
-	}
-
-	schema := dataSchema
-	if schema.Ref != "" {
-		if isPageQuery {
-			resolved := derefSchemaRef(schema, registry)
-			if resolved != nil && resolved.Value != nil && resolved.Value.Type != nil && resolved.Value.Type.Is("array") {
-				itemType := registry.SchemaToType(resolved.Value.Items, nil)
-				used := collectTypeNamesFromSchema(resolved.Value.Items, registry)
-				return ReturnInfo{Type: "PageResult<" + itemType + ">", UsesPageResult: true}, used
-			}
-		}
-		name, err := registry.RegisterRef(schema.Ref)
-		if err != nil {
-			return ReturnInfo{Type: "any", IsVoid: false}, nil
-		}
-		return ReturnInfo{Type: name}, []string{name}
-	}
-
-	if schema.Value == nil {
-		return ReturnInfo{Type: "any"}, nil
-	}
-
-	if listItems := extractPageListItems(schema, registry); listItems != nil {
-		itemType := registry.SchemaToType(listItems, nil)
-		used := collectTypeNamesFromSchema(listItems, registry)
-		return ReturnInfo{Type: "PageResult<" + itemType + ">", UsesPageResult: true}, used
-	}
-
-	if schema.Value.Type != nil && schema.Value.Type.Is("array") {
-		itemType := registry.SchemaToType(schema.Value.Items, nil)
-		used := collectTypeNamesFromSchema(schema.Value.Items, registry)
-		if isPageQuery {
-			return ReturnInfo{Type: "PageResult<" + itemType + ">", UsesPageResult: true}, used
-		}
-		return ReturnInfo{Type: itemType + "[]"}, used
-	}
-
-	inlineName := registry.RegisterInline(opName+"Result", schema, "")
-	return ReturnInfo{Type: inlineName}, []string{inlineName}
-}
-
-type paginationShape struct {
-	ListSchema *openapi3.SchemaRef
-	HasCount   bool
-}
-
-func extractPageListItems(schemaRef *openapi3.SchemaRef, registry *TypeRegistry) *openapi3.SchemaRef {
-	shape := collectPaginationShape(schemaRef, registry, map[*openapi3.SchemaRef]struct{}{})
-	if !shape.HasCount || shape.ListSchema == nil {
-		return nil
-	}
-	resolvedListSchema := derefSchemaRef(shape.ListSchema, registry)
-	if resolvedListSchema == nil || resolvedListSchema.Value == nil {
-		return nil
-	}
-	if resolvedListSchema.Value.Type == nil || !resolvedListSchema.Value.Type.Is("array") {
-		return nil
-	}
-	return resolvedListSchema.Value.Items
-}
-
-func collectPaginationShape(schemaRef *openapi3.SchemaRef, registry *TypeRegistry, visited map[*openapi3.SchemaRef]struct{}) paginationShape {
-	if schemaRef == nil {
-		return paginationShape{}
-	}
-	if _, ok := visited[schemaRef]; ok {
-		return paginationShape{}
-	}
-	visited[schemaRef] = struct{}{}
-
-	resolvedSchemaRef := derefSchemaRef(schemaRef, registry)
-	if resolvedSchemaRef == nil || resolvedSchemaRef.Value == nil {
-		return paginationShape{}
-	}
-
-	schema := resolvedSchemaRef.Value
-	shape := paginationShape{}
-
-	if schema.Properties != nil {
-		if _, ok := schema.Properties["count"]; ok {
-			shape.HasCount = true
+		if _, exists := seen[trimmed]; exists {
+			continue
 		}
-		shape.ListSchema = pickPreferredListSchema(shape.ListSchema, schema.Properties["list"], registry)
-	}
-
-	for _, part := range schema.AllOf {
-		partShape := collectPaginationShape(part, registry, visited)
-		shape.HasCount = shape.HasCount || partShape.HasCount
-		shape.ListSchema = pickPreferredListSchema(shape.ListSchema, partShape.ListSchema, registry)
-	}
-
-	return shape
-}
-
-func pickPreferredListSchema(current *openapi3.SchemaRef, candidate *openapi3.SchemaRef, registry *TypeRegistry) *openapi3.SchemaRef {
-	if candidate == nil {
-		return current
-	}
-	if current == nil {
-		return candidate
-	}
-	candidateIsArray := isArraySchema(candidate, registry)
-	currentIsArray := isArraySchema(current, registry)
-	if candidateIsArray && !currentIsArray {
-		return candidate
-	}
-	if candidateIsArray && currentIsArray {
-		return candidate
-	}
-	return current
-}
-
-func isArraySchema(schemaRef *openapi3.SchemaRef, registry *TypeRegistry) bool {
-	resolvedSchemaRef := derefSchemaRef(schemaRef, registry)
-	if resolvedSchemaRef == nil || resolvedSchemaRef.Value == nil {
-		return false
+		seen[trimmed] = struct{}{}
+		normalized = append(normalized, trimmed)
 	}
-	if resolvedSchemaRef.Value.Type == nil {
-		return false
+	if len(normalized) == 0 {
+		return defaultIncludeDirs
 	}
-	return resolvedSchemaRef.Value.Type.Is("array")
+	return normalized
 }
 
-func collectTypeNamesFromSchema(schemaRef *openapi3.SchemaRef, registry *TypeRegistry) []string {
-	if schemaRef == nil {
-		return nil
-	}
-	if schemaRef.Ref != "" {
-		name, err := registry.RegisterRef(schemaRef.Ref)
-		if err != nil {
-			return nil
-		}
-		return []string{name}
-	}
-
-	schema := schemaRef.Value
-	if schema == nil {
+func mapKeysSorted(values map[string]struct{}) []string {
+	if len(values) == 0 {
 		return nil
 	}
-
-	var names []string
-	if schema.Items != nil {
-		names = append(names, collectTypeNamesFromSchema(schema.Items, registry)...)
-	}
-
-	for _, prop := range schema.Properties {
-		names = append(names, collectTypeNamesFromSchema(prop, registry)...)
-	}
-
-	return uniqueStrings(names)
-}
-
-func derefSchemaRef(schemaRef *openapi3.SchemaRef, registry *TypeRegistry) *openapi3.SchemaRef {
-	if schemaRef == nil || registry == nil {
-		return schemaRef
-	}
-	if schemaRef.Ref == "" {
-		return schemaRef
-	}
-	resolved := registry.resolveRefSchema(schemaRef.Ref)
-	if resolved != nil {
-		return resolved
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
 	}
-	return schemaRef
+	sort.Strings(keys)
+	return keys
 }
 
 func uniqueStrings(items []string) []string {
@@ -859,189 +462,6 @@ func uniqueStrings(items []string) []string {
 	return result
 }
 
-func extractDataSchema(schemaRef *openapi3.SchemaRef, registry *TypeRegistry) *openapi3.SchemaRef {
-	if schemaRef == nil {
-		return nil
-	}
-	if schemaRef.Ref != "" {
-		refSchema := registry.resolveRefSchema(schemaRef.Ref)
-		if refSchema != nil {
-			schemaRef = refSchema
-		}
-	}
-	if schemaRef == nil || schemaRef.Value == nil {
-		return nil
-	}
-
-	schema := schemaRef.Value
-	if len(schema.AllOf) > 0 {
-		for _, item := range schema.AllOf {
-			if item == nil || item.Value == nil {
-				continue
-			}
-			if dataSchema, ok := item.Value.Properties["data"]; ok {
-				if !isEmptySchema(dataSchema) {
-					return dataSchema
-				}
-			}
-		}
-	}
-
-	if dataSchema, ok := schema.Properties["data"]; ok {
-		if !isEmptySchema(dataSchema) {
-			return dataSchema
-		}
-	}
-
-	return nil
-}
-
-func isEmptySchema(schemaRef *openapi3.SchemaRef) bool {
-	if schemaRef == nil {
-		return true
-	}
-	if schemaRef.Ref != "" {
-		return false
-	}
-	schema := schemaRef.Value
-	if schema == nil {
-		return true
-	}
-	if schema.Type != nil && len(*schema.Type) > 0 {
-		return false
-	}
-	if len(schema.Properties) > 0 || schema.Items != nil || len(schema.AllOf) > 0 || len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 || len(schema.Enum) > 0 {
-		return false
-	}
-	return true
-}
-
-func buildErrorText(summary string) string {
-	trimmed := strings.TrimSpace(summary)
-	if trimmed == "" {
-		return "请求失败"
-	}
-	if strings.HasSuffix(trimmed, "失败") {
-		return trimmed
-	}
-	return trimmed + "失败"
-}
-
-func formatRawParamNames(params []RawParam, excludePage bool) string {
-	if len(params) == 0 {
-		return "-"
-	}
-	names := make([]string, 0, len(params))
-	for _, param := range params {
-		if excludePage && isPageParamName(param.Name) {
-			continue
-		}
-		if param.Name == "" {
-			continue
-		}
-		names = append(names, param.Name)
-	}
-	if len(names) == 0 {
-		return "-"
-	}
-	return strings.Join(names, ",")
-}
-
-func formatQueryLog(query *QueryInfo, isPageQuery bool) string {
-	if query == nil {
-		return "-"
-	}
-	suffix := ""
-	if query.Optional {
-		suffix = "?"
-	}
-	pageSuffix := ""
-	if isPageQuery {
-		pageSuffix = "+PageParam"
-	}
-	return query.TypeName + suffix + pageSuffix
-}
-
-func formatBodyLog(body *BodyInfo) string {
-	if body == nil {
-		return "-"
-	}
-	suffix := ""
-	if body.Optional {
-		suffix = "?"
-	}
-	formSuffix := ""
-	if body.IsForm {
-		formSuffix = "(form)"
-	}
-	return body.TypeName + suffix + formSuffix
-}
-
-func SplitAndRenderAPI(ops []Operation, modelImports []string, usesPageResult bool) []string {
-	if len(ops) == 0 {
-		return nil
-	}
-	maxLines := 500
-	opStrings := make([]string, 0, len(ops))
-	opLines := make([]int, 0, len(ops))
-
-	for _, op := range ops {
-		content := RenderOperation(op)
-		opStrings = append(opStrings, content)
-		opLines = append(opLines, countLines(content))
-	}
-
-	header := RenderAPIFile([]Operation{}, modelImports, usesPageResult)
-	headerLines := countLines(header)
-
-	var files []string
-	var buffer []string
-	lineCount := headerLines
-
-	for idx, content := range opStrings {
-		lines := opLines[idx]
-		if lineCount+lines > maxLines && len(buffer) > 0 {
-			files = append(files, renderAPIFromChunks(buffer, header, headerLines))
-			buffer = nil
-			lineCount = headerLines
-		}
-		buffer = append(buffer, content)
-		lineCount += lines
-	}
-
-	if len(buffer) > 0 {
-		files = append(files, renderAPIFromChunks(buffer, header, headerLines))
-	}
-
-	return files
-}
-
-func renderAPIFromChunks(chunks []string, header string, headerLines int) string {
-	if len(chunks) == 0 {
-		return ""
-	}
-	var b strings.Builder
-	b.WriteString(header)
-	if headerLines > 0 && !strings.HasSuffix(header, "\n\n") {
-		b.WriteString("\n")
-	}
-	for idx, chunk := range chunks {
-		if idx > 0 {
-			b.WriteString("\n")
-		}
-		b.WriteString(chunk)
-		b.WriteString("\n")
-	}
-	return b.String()
-}
-
-func countLines(content string) int {
-	if content == "" {
-		return 0
-	}
-	return strings.Count(content, "\n") + 1
-}
-
 func typesOf(values ...string) *openapi3.Types {
 	if len(values) == 0 {
 		return nil
@@ -1049,135 +469,3 @@ func typesOf(values ...string) *openapi3.Types {
 	types := openapi3.Types(values)
 	return &types
 }
-
-func extractTypeNames(defs []*TypeDef) []string {
-	names := make([]string, 0, len(defs))
-	for _, def := range defs {
-		if def == nil || def.Name == "" {
-			continue
-		}
-		names = append(names, def.Name)
-	}
-	sort.Strings(names)
-	return names
-}
-
-func renderModelBundle(defs []*TypeDef, registry *TypeRegistry) (string, int) {
-	if len(defs) == 0 {
-		return "", 0
-	}
-	var b strings.Builder
-	if needsPageParamImport(defs) {
-		b.WriteString("import type { PageParam } from '@/api';\n\n")
-	}
-	modelDefinitions, _ := renderModelDefinitions(defs, registry)
-	if modelDefinitions != "" {
-		b.WriteString(modelDefinitions)
-	}
-	content := b.String()
-	if content == "" {
-		return "", 0
-	}
-	return content, countLines(content)
-}
-
-func renderModelDefinitions(defs []*TypeDef, registry *TypeRegistry) (string, int) {
-	if len(defs) == 0 {
-		return "", 0
-	}
-	var b strings.Builder
-	for idx, def := range defs {
-		content, _ := RenderType(def, registry)
-		if content == "" {
-			continue
-		}
-		if idx > 0 {
-			b.WriteString("\n")
-		}
-		b.WriteString(content)
-	}
-	modelContent := b.String()
-	if modelContent == "" {
-		return "", 0
-	}
-	return modelContent, countLines(modelContent)
-}
-
-func expandRegistryReferences(registry *TypeRegistry) {
-	if registry == nil {
-		return
-	}
-	visitedRefs := map[string]struct{}{}
-	visitedSchemas := map[*openapi3.Schema]struct{}{}
-	for {
-		before := len(registry.typeOrder)
-		defs := registry.Types()
-		for _, def := range defs {
-			walkSchemaRefs(def.Schema, registry, visitedRefs, visitedSchemas)
-		}
-		after := len(registry.typeOrder)
-		if after == before {
-			return
-		}
-	}
-}
-
-func needsPageParamImport(defs []*TypeDef) bool {
-	for _, def := range defs {
-		if def == nil {
-			continue
-		}
-		for _, ext := range def.Extends {
-			if ext == "PageParam" {
-				return true
-			}
-		}
-	}
-	return false
-}
-
-func walkSchemaRefs(schemaRef *openapi3.SchemaRef, registry *TypeRegistry, visitedRefs map[string]struct{}, visitedSchemas map[*openapi3.Schema]struct{}) {
-	if schemaRef == nil || registry == nil {
-		return
-	}
-	if schemaRef.Ref != "" {
-		if _, ok := visitedRefs[schemaRef.Ref]; ok {
-			return
-		}
-		visitedRefs[schemaRef.Ref] = struct{}{}
-		_, _ = registry.RegisterRef(schemaRef.Ref)
-		if resolved := registry.resolveRefSchema(schemaRef.Ref); resolved != nil {
-			walkSchemaRefs(resolved, registry, visitedRefs, visitedSchemas)
-		}
-		return
-	}
-	schema := schemaRef.Value
-	if schema == nil {
-		return
-	}
-	if _, ok := visitedSchemas[schema]; ok {
-		return
-	}
-	visitedSchemas[schema] = struct{}{}
-	for _, ref := range schema.AllOf {
-		walkSchemaRefs(ref, registry, visitedRefs, visitedSchemas)
-	}
-	for _, ref := range schema.OneOf {
-		walkSchemaRefs(ref, registry, visitedRefs, visitedSchemas)
-	}
-	for _, ref := range schema.AnyOf {
-		walkSchemaRefs(ref, registry, visitedRefs, visitedSchemas)
-	}
-	if schema.Not != nil {
-		walkSchemaRefs(schema.Not, registry, visitedRefs, visitedSchemas)
-	}
-	if schema.Items != nil {
-		walkSchemaRefs(schema.Items, registry, visitedRefs, visitedSchemas)
-	}
-	for _, prop := range schema.Properties {
-		walkSchemaRefs(prop, registry, visitedRefs, visitedSchemas)
-	}
-	if schema.AdditionalProperties.Schema != nil {
-		walkSchemaRefs(schema.AdditionalProperties.Schema, registry, visitedRefs, visitedSchemas)
-	}
-}
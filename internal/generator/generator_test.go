@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
@@ -31,8 +32,8 @@ func TestGenerate_WritesRootIndexFile(t *testing.T) {
 		t.Fatalf("read generated root index failed: %v", err)
 	}
 
-	if string(indexContent) != renderRootIndexFile() {
-		t.Fatalf("unexpected root index content\n--- got ---\n%s\n--- want ---\n%s", string(indexContent), renderRootIndexFile())
+	if string(indexContent) != renderRootIndexFile(Envelope{}, []PaginationProfile{fiberxPaginationProfile}) {
+		t.Fatalf("unexpected root index content\n--- got ---\n%s\n--- want ---\n%s", string(indexContent), renderRootIndexFile(Envelope{}, []PaginationProfile{fiberxPaginationProfile}))
 	}
 }
 
@@ -136,3 +137,151 @@ func buildCrossGroupDuplicateModelDoc() *openapi3.T {
 
 	return doc
 }
+
+func TestGenerate_CheckModeReportsDriftWithoutWriting(t *testing.T) {
+	doc := &openapi3.T{Paths: openapi3.NewPaths()}
+	doc.Paths.Set("/api/v1/demo", &openapi3.PathItem{Get: &openapi3.Operation{Summary: "查询示例"}})
+
+	outputDir := filepath.Join(t.TempDir(), "api")
+	if _, err := New(doc, Options{OutputDir: outputDir, CleanOutput: true}).Generate(); err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+
+	report, err := New(doc, Options{OutputDir: outputDir, CleanOutput: true, Check: true}).Generate()
+	if err != nil {
+		t.Fatalf("check returned error: %v", err)
+	}
+	if len(report.Changes) != 0 {
+		t.Fatalf("fresh output should be up to date, got %+v", report.Changes)
+	}
+
+	if err := os.WriteFile(filepath.Join(outputDir, "index.ts"), []byte("stale"), 0o644); err != nil {
+		t.Fatalf("modify root index failed: %v", err)
+	}
+	if err := os.Remove(filepath.Join(outputDir, "demo", "index.ts")); err != nil {
+		t.Fatalf("remove group index failed: %v", err)
+	}
+	staleDir := filepath.Join(outputDir, "legacy")
+	if err := os.MkdirAll(staleDir, 0o755); err != nil {
+		t.Fatalf("create stale group failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(staleDir, "index.ts"), []byte("export {}"), 0o644); err != nil {
+		t.Fatalf("write stale group failed: %v", err)
+	}
+	for _, name := range []string{"api_2.ts", "model/Old.ts", "custom.ts", "notes.md"} {
+		if err := os.WriteFile(filepath.Join(outputDir, "demo", filepath.FromSlash(name)), []byte("export {}"), 0o644); err != nil {
+			t.Fatalf("write leftover file failed: %v", err)
+		}
+	}
+
+	report, err = New(doc, Options{OutputDir: outputDir, CleanOutput: true, Check: true}).Generate()
+	if err != nil {
+		t.Fatalf("check returned error: %v", err)
+	}
+	want := []FileChange{
+		{Path: "demo/api_2.ts", Kind: ChangeRemoved},
+		{Path: "demo/index.ts", Kind: ChangeAdded},
+		{Path: "index.ts", Kind: ChangeChanged},
+		{Path: "legacy/index.ts", Kind: ChangeRemoved},
+	}
+	if len(report.Changes) != len(want) {
+		t.Fatalf("unexpected changes: %+v", report.Changes)
+	}
+	for idx, change := range report.Changes {
+		if change != want[idx] {
+			t.Fatalf("unexpected change %d: got %+v want %+v", idx, change, want[idx])
+		}
+	}
+
+	if content, _ := os.ReadFile(filepath.Join(outputDir, "index.ts")); string(content) != "stale" {
+		t.Fatalf("check mode must not write files")
+	}
+	if _, err := os.Stat(staleDir); err != nil {
+		t.Fatalf("check mode must not prune stale groups: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "demo", "api_2.ts")); err != nil {
+		t.Fatalf("check mode must not prune stale files: %v", err)
+	}
+}
+
+func TestGenerate_SkipsUnchangedFilesAndCountsWrites(t *testing.T) {
+	doc := &openapi3.T{Paths: openapi3.NewPaths()}
+	doc.Paths.Set("/api/v1/demo", &openapi3.PathItem{Get: &openapi3.Operation{Summary: "查询示例"}})
+
+	outputDir := filepath.Join(t.TempDir(), "api")
+	first, err := New(doc, Options{OutputDir: outputDir, CleanOutput: true}).Generate()
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if first.Written != 2 || first.Unchanged != 0 || first.Deleted != 0 {
+		t.Fatalf("unexpected first run counts: %+v", *first)
+	}
+
+	rootIndex := filepath.Join(outputDir, "index.ts")
+	staleTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(rootIndex, staleTime, staleTime); err != nil {
+		t.Fatalf("set root index mtime failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "demo", "index.ts"), []byte("stale"), 0o644); err != nil {
+		t.Fatalf("modify group index failed: %v", err)
+	}
+	staleDir := filepath.Join(outputDir, "legacy")
+	if err := os.MkdirAll(filepath.Join(staleDir, "model"), 0o755); err != nil {
+		t.Fatalf("create stale group failed: %v", err)
+	}
+	for _, name := range []string{"index.ts", "model/index.ts"} {
+		if err := os.WriteFile(filepath.Join(staleDir, name), []byte("export {}"), 0o644); err != nil {
+			t.Fatalf("write stale group file failed: %v", err)
+		}
+	}
+
+	second, err := New(doc, Options{OutputDir: outputDir, CleanOutput: true}).Generate()
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if second.Written != 1 || second.Unchanged != 1 || second.Deleted != 2 {
+		t.Fatalf("unexpected second run counts: %+v", *second)
+	}
+	info, err := os.Stat(rootIndex)
+	if err != nil {
+		t.Fatalf("stat root index failed: %v", err)
+	}
+	if !info.ModTime().Equal(staleTime) {
+		t.Fatalf("unchanged root index should not be rewritten: mtime %v", info.ModTime())
+	}
+
+	entries, err := os.ReadDir(filepath.Join(outputDir, "demo"))
+	if err != nil {
+		t.Fatalf("read group dir failed: %v", err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Fatalf("temp file left behind: %s", entry.Name())
+		}
+	}
+}
+
+func TestGenerate_KeepsUserFilesInGroupDir(t *testing.T) {
+	doc := &openapi3.T{Paths: openapi3.NewPaths()}
+	doc.Paths.Set("/api/v1/orders", &openapi3.PathItem{Get: &openapi3.Operation{Summary: "查询订单"}})
+
+	outputDir := filepath.Join(t.TempDir(), "api")
+	if _, err := New(doc, Options{OutputDir: outputDir, CleanOutput: true}).Generate(); err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	custom := filepath.Join(outputDir, "orders", "custom.ts")
+	if err := os.WriteFile(custom, []byte("export const custom = 1;\n"), 0o644); err != nil {
+		t.Fatalf("write user file failed: %v", err)
+	}
+
+	report, err := New(doc, Options{OutputDir: outputDir, CleanOutput: true}).Generate()
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if report.Deleted != 0 {
+		t.Fatalf("regeneration should not delete user files, deleted %d: %+v", report.Deleted, report.Changes)
+	}
+	if content, err := os.ReadFile(custom); err != nil || string(content) != "export const custom = 1;\n" {
+		t.Fatalf("user file should survive regeneration: content=%q err=%v", content, err)
+	}
+}
@@ -19,6 +19,51 @@ type GoStructOptionality struct {
 // ParseGoOptionalFieldsByType parses Go struct definitions and returns optional json fields for each type.
 // Rule: field is optional only when its json tag includes ",omitempty".
 func ParseGoOptionalFieldsByType(rootDir string, includeDirs []string) (map[string][]GoStructOptionality, error) {
+	files, err := GoSourceFiles(rootDir, includeDirs)
+	if err != nil {
+		return nil, err
+	}
+
+	fileSet := token.NewFileSet()
+	optionalFieldsByType := map[string][]GoStructOptionality{}
+
+	for _, path := range files {
+		parsedFile, parseErr := parser.ParseFile(fileSet, path, nil, parser.SkipObjectResolution)
+		if parseErr != nil {
+			return nil, fmt.Errorf("parse go file %s failed: %w", path, parseErr)
+		}
+
+		for _, declaration := range parsedFile.Decls {
+			generalDeclaration, ok := declaration.(*ast.GenDecl)
+			if !ok || generalDeclaration.Tok != token.TYPE {
+				continue
+			}
+			for _, specification := range generalDeclaration.Specs {
+				typeSpecification, ok := specification.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				structType, ok := typeSpecification.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+
+				typeName := sanitizeTypeName(typeSpecification.Name.Name)
+				optionalFields, fieldOrder := extractOptionalJSONFields(structType)
+				optionalFieldsByType[typeName] = append(optionalFieldsByType[typeName], GoStructOptionality{
+					Fields:     optionalFields,
+					FieldOrder: fieldOrder,
+				})
+			}
+		}
+	}
+
+	return optionalFieldsByType, nil
+}
+
+// GoSourceFiles lists the non-test Go files under rootDir that the optionality scan reads: those
+// below one of includeDirs (default schema and fiberx), skipping vendor and hidden directories.
+func GoSourceFiles(rootDir string, includeDirs []string) ([]string, error) {
 	trimmedRoot := strings.TrimSpace(rootDir)
 	if trimmedRoot == "" {
 		return nil, fmt.Errorf("root dir is empty")
@@ -43,7 +88,7 @@ func ParseGoOptionalFieldsByType(rootDir string, includeDirs []string) (map[stri
 		}
 		if dirEntry.IsDir() {
 			name := dirEntry.Name()
-			if name == ".git" || name == "vendor" || strings.HasPrefix(name, ".") {
+			if path != trimmedRoot && (name == ".git" || name == "vendor" || strings.HasPrefix(name, ".")) {
 				return filepath.SkipDir
 			}
 			return nil
@@ -60,42 +105,7 @@ func ParseGoOptionalFieldsByType(rootDir string, includeDirs []string) (map[stri
 	if err != nil {
 		return nil, fmt.Errorf("scan go files failed: %w", err)
 	}
-
-	fileSet := token.NewFileSet()
-	optionalFieldsByType := map[string][]GoStructOptionality{}
-
-	for _, path := range files {
-		parsedFile, parseErr := parser.ParseFile(fileSet, path, nil, parser.SkipObjectResolution)
-		if parseErr != nil {
-			return nil, fmt.Errorf("parse go file %s failed: %w", path, parseErr)
-		}
-
-		for _, declaration := range parsedFile.Decls {
-			generalDeclaration, ok := declaration.(*ast.GenDecl)
-			if !ok || generalDeclaration.Tok != token.TYPE {
-				continue
-			}
-			for _, specification := range generalDeclaration.Specs {
-				typeSpecification, ok := specification.(*ast.TypeSpec)
-				if !ok {
-					continue
-				}
-				structType, ok := typeSpecification.Type.(*ast.StructType)
-				if !ok {
-					continue
-				}
-
-				typeName := sanitizeTypeName(typeSpecification.Name.Name)
-				optionalFields, fieldOrder := extractOptionalJSONFields(structType)
-				optionalFieldsByType[typeName] = append(optionalFieldsByType[typeName], GoStructOptionality{
-					Fields:     optionalFields,
-					FieldOrder: fieldOrder,
-				})
-			}
-		}
-	}
-
-	return optionalFieldsByType, nil
+	return files, nil
 }
 
 func shouldIncludeOptionalitySourceFile(rootDir string, path string, includeDirSet map[string]struct{}) bool {
@@ -0,0 +1,159 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	GroupByPath = "path"
+	GroupByTag  = "tag"
+
+	groupNameExtension = "x-group-name"
+)
+
+// GroupRule maps operations to a group directory. When both PathPrefix and Tag are set, both must match.
+type GroupRule struct {
+	PathPrefix string
+	Tag        string
+	Group      string
+}
+
+// Grouping selects how operations are split into group directories.
+// The zero value groups by the first path segment after /api/vN.
+type Grouping struct {
+	// Strategy is GroupByPath (default) or GroupByTag.
+	Strategy string
+	// Rules are checked in order before the strategy; the first match wins.
+	Rules []GroupRule
+}
+
+func (g Grouping) validate() error {
+	switch g.Strategy {
+	case "", GroupByPath, GroupByTag:
+	default:
+		return fmt.Errorf("unknown grouping strategy %q (expected %s or %s)", g.Strategy, GroupByPath, GroupByTag)
+	}
+	for idx, rule := range g.Rules {
+		if rule.PathPrefix == "" && rule.Tag == "" {
+			return fmt.Errorf("grouping rule %d needs a path prefix or a tag", idx)
+		}
+		if _, ok := asciiGroupName(rule.Group); !ok {
+			return fmt.Errorf("grouping rule %d has invalid group %q: use ASCII letters and digits", idx, rule.Group)
+		}
+	}
+	return nil
+}
+
+// groupFor resolves the group directory of an operation. Precedence: the operation's x-group-name,
+// then the first matching rule, then the strategy. tagGroups holds x-group-name overrides from tag objects.
+// untranslated is the tag that could not be transliterated when the path group was used instead.
+func (g Grouping) groupFor(op RawOperation, tagGroups map[string]string) (group string, untranslated string) {
+	if name, ok := asciiGroupName(op.GroupName); ok {
+		return name, ""
+	}
+	for _, rule := range g.Rules {
+		if rule.matches(op) {
+			name, _ := asciiGroupName(rule.Group)
+			return name, ""
+		}
+	}
+
+	pathGroup := groupFromPath(op.Path)
+	if g.Strategy != GroupByTag || len(op.Tags) == 0 {
+		return pathGroup, ""
+	}
+	tag := op.Tags[0]
+	if name, ok := asciiGroupName(tagGroups[tag]); ok {
+		return name, ""
+	}
+	if name, ok := asciiGroupName(tag); ok {
+		return name, ""
+	}
+	return pathGroup, tag
+}
+
+func (r GroupRule) matches(op RawOperation) bool {
+	if r.PathPrefix != "" && !strings.HasPrefix(op.Path, r.PathPrefix) {
+		return false
+	}
+	if r.Tag == "" {
+		return true
+	}
+	for _, tag := range op.Tags {
+		if tag == r.Tag {
+			return true
+		}
+	}
+	return false
+}
+
+// tagGroupNames collects x-group-name overrides declared on top-level tag objects.
+func tagGroupNames(doc *openapi3.T) map[string]string {
+	names := map[string]string{}
+	if doc == nil {
+		return names
+	}
+	for _, tag := range doc.Tags {
+		if tag == nil {
+			continue
+		}
+		if name := stringExtension(tag.Extensions, groupNameExtension); name != "" {
+			names[tag.Name] = name
+		}
+	}
+	return names
+}
+
+func stringExtension(extensions map[string]any, key string) string {
+	value, ok := extensions[key].(string)
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(value)
+}
+
+// asciiGroupName transliterates a name into a camelCase group directory. It fails when letters
+// remain that have no ASCII form (e.g. CJK), so callers can fall back instead of emitting them.
+func asciiGroupName(name string) (string, bool) {
+	if strings.TrimSpace(name) == "" {
+		return "", false
+	}
+	var b strings.Builder
+	for _, r := range name {
+		if folded, ok := latinFolding[r]; ok {
+			b.WriteString(folded)
+			continue
+		}
+		if r > unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return "", false
+		}
+		b.WriteRune(r)
+	}
+	group := sanitizePathSegment(b.String())
+	if group == "default" && !strings.EqualFold(strings.TrimSpace(name), "default") {
+		return "", false
+	}
+	return group, true
+}
+
+// latinFolding maps accented Latin letters to their ASCII base letters.
+var latinFolding = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'æ': "ae",
+	'À': "A", 'Á': "A", 'Â': "A", 'Ã': "A", 'Ä': "A", 'Å': "A", 'Ā': "A", 'Æ': "AE",
+	'ç': "c", 'č': "c", 'Ç': "C", 'Č': "C",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ě': "e",
+	'È': "E", 'É': "E", 'Ê': "E", 'Ë': "E", 'Ē': "E", 'Ě': "E",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ī': "i",
+	'Ì': "I", 'Í': "I", 'Î': "I", 'Ï': "I", 'Ī': "I",
+	'ñ': "n", 'ň': "n", 'Ñ': "N", 'Ň': "N",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'œ': "oe",
+	'Ò': "O", 'Ó': "O", 'Ô': "O", 'Õ': "O", 'Ö': "O", 'Ø': "O", 'Ō': "O", 'Œ': "OE",
+	'ř': "r", 'Ř': "R", 'š': "s", 'Š': "S", 'ß': "ss", 'ť': "t", 'Ť': "T",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ū': "u", 'ů': "u",
+	'Ù': "U", 'Ú': "U", 'Û': "U", 'Ü': "U", 'Ū': "U", 'Ů': "U",
+	'ý': "y", 'ÿ': "y", 'Ý': "Y", 'ž': "z", 'Ž': "Z",
+}
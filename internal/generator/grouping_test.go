@@ -0,0 +1,48 @@
+package generator
+
+import "testing"
+
+func TestGroupingGroupFor_TagStrategy(t *testing.T) {
+	grouping := Grouping{Strategy: GroupByTag}
+	tagGroups := map[string]string{"用户管理": "users"}
+
+	cases := []struct {
+		name             string
+		op               RawOperation
+		wantGroup        string
+		wantUntranslated string
+	}{
+		{name: "ascii tag", op: RawOperation{Path: "/api/v1/sys-api", Tags: []string{"System API"}}, wantGroup: "systemApi"},
+		{name: "latin tag", op: RawOperation{Path: "/api/v1/x", Tags: []string{"Catégories"}}, wantGroup: "categories"},
+		{name: "tag override", op: RawOperation{Path: "/api/v1/user", Tags: []string{"用户管理"}}, wantGroup: "users"},
+		{name: "operation override", op: RawOperation{Path: "/api/v1/user", Tags: []string{"用户管理"}, GroupName: "account"}, wantGroup: "account"},
+		{name: "cjk fallback", op: RawOperation{Path: "/api/v1/roles", Tags: []string{"角色管理"}}, wantGroup: "roles", wantUntranslated: "角色管理"},
+		{name: "untagged", op: RawOperation{Path: "/api/v1/menus"}, wantGroup: "menus"},
+	}
+	for _, tc := range cases {
+		group, untranslated := grouping.groupFor(tc.op, tagGroups)
+		if group != tc.wantGroup || untranslated != tc.wantUntranslated {
+			t.Fatalf("%s: got (%s, %q) want (%s, %q)", tc.name, group, untranslated, tc.wantGroup, tc.wantUntranslated)
+		}
+	}
+}
+
+func TestGroupingGroupFor_RulesWinOverStrategy(t *testing.T) {
+	grouping := Grouping{
+		Strategy: GroupByTag,
+		Rules: []GroupRule{
+			{PathPrefix: "/api/v1/auth", Group: "auth"},
+			{Tag: "角色管理", Group: "roles"},
+		},
+	}
+
+	if group, _ := grouping.groupFor(RawOperation{Path: "/api/v1/auth/login", Tags: []string{"Login"}}, nil); group != "auth" {
+		t.Fatalf("path rule should win: got %s", group)
+	}
+	if group, _ := grouping.groupFor(RawOperation{Path: "/api/v1/role-menus", Tags: []string{"菜单", "角色管理"}}, nil); group != "roles" {
+		t.Fatalf("tag rule should match any operation tag: got %s", group)
+	}
+	if err := (Grouping{Rules: []GroupRule{{Tag: "x", Group: "用户"}}}).validate(); err == nil {
+		t.Fatalf("expected non-ASCII rule group to be rejected")
+	}
+}
@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gopkg-dev/swagger-ts-gen/internal/diff"
+	"github.com/gopkg-dev/swagger-ts-gen/internal/surface"
+)
+
+const (
+	// ManifestFile is the machine-readable description of the emitted API, written to the
+	// output root when Options.Manifest is set.
+	ManifestFile = "api.manifest.json"
+	// ChangelogFile collects one section per run whose API differs from the previous manifest.
+	ChangelogFile = "CHANGELOG.api.md"
+)
+
+const changelogTitle = "# API changelog\n"
+
+// addManifest adds the manifest of this run and, when the output directory holds a manifest
+// from a previous run, a changelog section describing how the API changed since then.
+func (g *Generator) addManifest(output *renderedOutput, api *surface.Surface, report *Report) error {
+	content, err := json.MarshalIndent(api, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode manifest failed: %w", err)
+	}
+	output.addFile(ManifestFile, string(content)+"\n")
+
+	previous, err := readManifest(filepath.Join(g.outputDir, ManifestFile))
+	if err != nil || previous == nil {
+		return err
+	}
+	report.APIChanges = diff.Compare(previous, api)
+	if len(report.APIChanges) == 0 {
+		return nil
+	}
+
+	existing, err := os.ReadFile(filepath.Join(g.outputDir, ChangelogFile))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("read %s failed: %w", ChangelogFile, err)
+	}
+	section, err := renderChangelogSection(g.now(), report.APIChanges)
+	if err != nil {
+		return err
+	}
+	output.addFile(ChangelogFile, prependChangelogSection(string(existing), section))
+	return nil
+}
+
+// readManifest returns nil without error when no previous manifest exists.
+func readManifest(path string) (*surface.Surface, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read previous manifest failed: %w", err)
+	}
+	var api surface.Surface
+	if err := json.Unmarshal(data, &api); err != nil {
+		return nil, fmt.Errorf("parse previous manifest %s failed (delete it to start over): %w", path, err)
+	}
+	return &api, nil
+}
+
+func renderChangelogSection(now time.Time, changes []diff.Change) (string, error) {
+	var b bytes.Buffer
+	b.WriteString("## " + now.UTC().Format(time.RFC3339) + "\n\n")
+	if err := diff.Write(&b, "markdown", changes); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// prependChangelogSection keeps the newest section first, right below the title.
+func prependChangelogSection(existing string, section string) string {
+	body := strings.TrimLeft(strings.TrimPrefix(existing, changelogTitle), "\n")
+	if body == "" {
+		return changelogTitle + "\n" + section
+	}
+	return changelogTitle + "\n" + section + "\n" + body
+}
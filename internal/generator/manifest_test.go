@@ -0,0 +1,56 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGenerate_ManifestRecordsAPIChangesBetweenRuns(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "api")
+	run := func(fieldType string, at time.Time) *Report {
+		t.Helper()
+		gen := New(buildSingleUserDoc("age", fieldType), Options{OutputDir: outputDir, Manifest: true})
+		gen.now = func() time.Time { return at }
+		report, err := gen.Generate()
+		if err != nil {
+			t.Fatalf("Generate returned error: %v", err)
+		}
+		return report
+	}
+
+	first := run("integer", time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC))
+	if len(first.APIChanges) != 0 {
+		t.Fatalf("first run has no previous manifest: %+v", first.APIChanges)
+	}
+	readGeneratedFile(t, outputDir, ManifestFile)
+	if _, err := os.Stat(filepath.Join(outputDir, ChangelogFile)); !os.IsNotExist(err) {
+		t.Fatalf("first run should not write a changelog: %v", err)
+	}
+
+	second := run("string", time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC))
+	if len(second.APIChanges) != 1 || !second.APIChanges[0].Breaking {
+		t.Fatalf("expected one breaking change: %+v", second.APIChanges)
+	}
+	run("boolean", time.Date(2026, 1, 3, 17, 0, 0, 0, time.FixedZone("CST", 8*60*60)))
+	run("boolean", time.Date(2026, 1, 4, 9, 0, 0, 0, time.UTC))
+
+	want := `# API changelog
+
+## 2026-01-03T09:00:00Z
+
+### Breaking changes
+
+- ` + "`users.User.age`" + `: type changed from string to boolean
+
+## 2026-01-02T09:00:00Z
+
+### Breaking changes
+
+- ` + "`users.User.age`" + `: type changed from number to string
+`
+	if got := readGeneratedFile(t, outputDir, ChangelogFile); got != want {
+		t.Fatalf("unexpected changelog\n--- got ---\n%s\n--- want ---\n%s", got, want)
+	}
+}
@@ -13,6 +13,11 @@ type QueryInfo struct {
 	Optional bool
 }
 
+type HeaderInfo struct {
+	TypeName string
+	Optional bool
+}
+
 type BodyInfo struct {
 	TypeName string
 	Optional bool
@@ -23,17 +28,39 @@ type ReturnInfo struct {
 	Type           string
 	IsVoid         bool
 	UsesPageResult bool
+	PageResultType string
+	IsDownload     bool
+}
+
+type ErrorInfo struct {
+	// Key is the response key (400, 4XX or default); Status is 0 unless it is a status code.
+	Key         string
+	Status      int
+	TypeName    string
+	Description string
 }
 
 type Operation struct {
-	Name       string
-	Summary    string
-	Method     string
-	Path       string
+	Name    string
+	Summary string
+	Method  string
+	Path    string
+	// URL is the request URL: Path prefixed with the source base URL. Empty means Path.
+	URL        string
 	Group      string
 	PathParams []Param
 	Query      *QueryInfo
+	Headers    *HeaderInfo
+	Cookies    []Param
 	Body       *BodyInfo
 	Return     ReturnInfo
 	ErrorText  string
+	Errors     []ErrorInfo
+	ErrorType  string
+	// SuccessStatus is the HTTP status envelope failures are rejected with.
+	SuccessStatus int
+	Envelope      Envelope
+	// Imports lists the types the function signature imports: shared scalar types from '@/api'
+	// and x-ts-type names from their x-ts-import module.
+	Imports []TypeImport
 }
@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// JSON Schema 2020-12 keywords used by OpenAPI 3.1 that kin-openapi keeps in Schema.Extensions.
+const (
+	constKeyword       = "const"
+	prefixItemsKeyword = "prefixItems"
+)
+
+// unsupportedKeywords have no TypeScript mapping; they are ignored with a warning.
+var unsupportedKeywords = []string{
+	"if", "then", "else", "dependentSchemas", "dependentRequired", "patternProperties",
+	"unevaluatedProperties", "unevaluatedItems", "contains", "propertyNames", "$dynamicRef",
+}
+
+// constType renders the literal type of a const keyword.
+func constType(schema *openapi3.Schema) (string, bool) {
+	value, ok := schema.Extensions[constKeyword]
+	if !ok {
+		return "", false
+	}
+	return enumToType([]any{value}), true
+}
+
+// prefixItems decodes the prefixItems keyword once per schema so refs inside it can be walked
+// and rendered like regular schema refs.
+func (r *TypeRegistry) prefixItems(schema *openapi3.Schema) openapi3.SchemaRefs {
+	raw, ok := schema.Extensions[prefixItemsKeyword]
+	if !ok {
+		return nil
+	}
+	if items, ok := r.prefixItemsCache[schema]; ok {
+		return items
+	}
+	var items openapi3.SchemaRefs
+	data, err := json.Marshal(raw)
+	if err == nil {
+		err = json.Unmarshal(data, &items)
+	}
+	if err != nil {
+		r.warnf("invalid %s: %v", prefixItemsKeyword, err)
+		items = nil
+	}
+	r.prefixItemsCache[schema] = items
+	return items
+}
+
+// tupleType renders prefixItems as a TS tuple; items (when present) types the rest elements.
+func (r *TypeRegistry) tupleType(schema *openapi3.Schema, prefix openapi3.SchemaRefs, deps map[string]struct{}) string {
+	parts := make([]string, 0, len(prefix)+1)
+	for _, item := range prefix {
+		parts = append(parts, r.SchemaToType(item, deps))
+	}
+	if schema.Items != nil {
+		parts = append(parts, "...Array<"+r.SchemaToType(schema.Items, deps)+">")
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// multiTypeUnion renders type arrays such as [string, integer, null] as a union of each type.
+func (r *TypeRegistry) multiTypeUnion(schema *openapi3.Schema, deps map[string]struct{}) string {
+	var parts []string
+	nullable := false
+	for _, typ := range schema.Type.Slice() {
+		if typ == openapi3.TypeNull {
+			nullable = true
+			continue
+		}
+		single := *schema
+		single.Type = &openapi3.Types{typ}
+		parts = append(parts, r.schemaValueToType(&single, deps))
+	}
+	parts = uniqueStrings(parts)
+	if len(parts) == 0 {
+		parts = append(parts, "any")
+	}
+	if nullable {
+		parts = append(parts, "null")
+	}
+	return strings.Join(parts, " | ")
+}
+
+// warnUnsupportedKeywords reports keywords the generator cannot express in TypeScript.
+func (r *TypeRegistry) warnUnsupportedKeywords(schema *openapi3.Schema) {
+	var found []string
+	for _, keyword := range unsupportedKeywords {
+		if _, ok := schema.Extensions[keyword]; ok {
+			found = append(found, keyword)
+		}
+	}
+	if schema.Not != nil {
+		found = append(found, "not")
+	}
+	if len(found) > 0 {
+		r.warnf("unsupported schema keyword(s) %s ignored", strings.Join(found, ", "))
+	}
+}
+
+func (r *TypeRegistry) warnf(format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	if r.warnScope != "" {
+		message = r.warnScope + ": " + message
+	}
+	r.warnings[message] = struct{}{}
+}
+
+// Warnings returns the distinct warnings collected while rendering, sorted.
+func (r *TypeRegistry) Warnings() []string {
+	warnings := make([]string, 0, len(r.warnings))
+	for message := range r.warnings {
+		warnings = append(warnings, message)
+	}
+	sort.Strings(warnings)
+	return warnings
+}
@@ -0,0 +1,59 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestSchemaToType_MapsOpenAPI31Constructs(t *testing.T) {
+	components := openapi3.NewComponents()
+	components.Schemas = openapi3.Schemas{
+		"Label": {Value: &openapi3.Schema{Type: typesOf("object")}},
+	}
+	registry := NewTypeRegistry(&openapi3.T{Components: &components})
+
+	cases := []struct {
+		name   string
+		schema *openapi3.Schema
+		want   string
+	}{
+		{"const", &openapi3.Schema{Extensions: map[string]any{"const": "order"}}, "'order'"},
+		{"null", &openapi3.Schema{Type: typesOf("null")}, "null"},
+		{"type union", &openapi3.Schema{Type: &openapi3.Types{"string", "integer", "null"}}, "number | string | null"},
+		{"tuple", &openapi3.Schema{
+			Type: typesOf("array"),
+			Extensions: map[string]any{"prefixItems": []any{
+				map[string]any{"type": "number"},
+				map[string]any{"$ref": "#/components/schemas/Label"},
+			}},
+			Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: typesOf("string")}},
+		}, "[number, Label, ...Array<string>]"},
+	}
+	for _, tc := range cases {
+		deps := map[string]struct{}{}
+		if got := registry.SchemaToType(&openapi3.SchemaRef{Value: tc.schema}, deps); got != tc.want {
+			t.Fatalf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+	if len(registry.Warnings()) != 0 {
+		t.Fatalf("supported constructs should not warn: %v", registry.Warnings())
+	}
+}
+
+func TestRenderType_WarnsOnUnsupportedKeywords(t *testing.T) {
+	registry := NewTypeRegistry(&openapi3.T{})
+	def := &TypeDef{Name: "Rule", Schema: &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Extensions: map[string]any{"if": map[string]any{}, "then": map[string]any{}},
+	}}}
+
+	content, _ := RenderType(def, registry)
+	if !strings.Contains(content, "export type Rule = any;") {
+		t.Fatalf("unexpected content:\n%s", content)
+	}
+	warnings := registry.Warnings()
+	if len(warnings) != 1 || warnings[0] != "Rule: unsupported schema keyword(s) if, then ignored" {
+		t.Fatalf("unexpected warnings: %v", warnings)
+	}
+}
@@ -23,16 +23,33 @@ type RawBody struct {
 	IsFormData bool
 }
 
+// RawErrorResponse is a documented non-2xx response of an operation. Key is the response key:
+// a status code, a range such as 4XX, or default; Status is 0 unless Key is a status code.
+type RawErrorResponse struct {
+	Key         string
+	Status      int
+	Description string
+	Schema      *openapi3.SchemaRef
+}
+
 type RawOperation struct {
-	Name        string
-	Summary     string
-	Method      string
-	Path        string
-	Group       string
-	PathParams  []RawParam
-	QueryParams []RawParam
-	Body        *RawBody
-	Response    *openapi3.SchemaRef
+	Name         string
+	Summary      string
+	Method       string
+	Path         string
+	Group        string
+	Tags         []string
+	GroupName    string
+	PathParams   []RawParam
+	QueryParams  []RawParam
+	HeaderParams []RawParam
+	CookieParams []RawParam
+	Body         *RawBody
+	Response     *openapi3.SchemaRef
+	Download     bool
+	Errors       []RawErrorResponse
+	// SuccessStatus is the status of the success response, which envelope failures also carry.
+	SuccessStatus int
 }
 
 type methodOperation struct {
@@ -74,25 +91,34 @@ func ExtractOperations(doc *openapi3.T) ([]RawOperation, error) {
 
 			params := mergeParameters(doc, item.Parameters, op.Parameters)
 			pathParams := extractPathParams(params, path)
-			queryParams := extractQueryParams(params)
+			queryParams := extractParamsIn(params, openapi3.ParameterInQuery)
+			headerParams := extractHeaderParams(params)
+			cookieParams := extractParamsIn(params, openapi3.ParameterInCookie)
 
 			body, err := extractRequestBody(op)
 			if err != nil {
 				return nil, err
 			}
 
-			responseSchema := extractResponseSchema(op)
+			responseType, responseSchema := extractResponseSchema(op)
 
 			ops = append(ops, RawOperation{
-				Name:        opName,
-				Summary:     strings.TrimSpace(op.Summary),
-				Method:      method,
-				Path:        path,
-				Group:       groupFromPath(path),
-				PathParams:  pathParams,
-				QueryParams: queryParams,
-				Body:        body,
-				Response:    responseSchema,
+				Name:          opName,
+				Summary:       strings.TrimSpace(op.Summary),
+				Method:        method,
+				Path:          path,
+				Group:         groupFromPath(path),
+				Tags:          op.Tags,
+				GroupName:     stringExtension(op.Extensions, groupNameExtension),
+				PathParams:    pathParams,
+				QueryParams:   queryParams,
+				HeaderParams:  headerParams,
+				CookieParams:  cookieParams,
+				Body:          body,
+				Response:      responseSchema,
+				Download:      isDownloadResponse(responseType, responseSchema),
+				Errors:        extractErrorResponses(op),
+				SuccessStatus: successStatus(op),
 			})
 		}
 	}
@@ -185,18 +211,20 @@ func extractPathParams(params map[string]*openapi3.Parameter, path string) []Raw
 	return result
 }
 
-func extractQueryParams(params map[string]*openapi3.Parameter) []RawParam {
+// extractParamsIn returns the parameters of one location (query, header, cookie) sorted by name.
+func extractParamsIn(params map[string]*openapi3.Parameter, in string) []RawParam {
+	prefix := in + ":"
 	var names []string
 	for key := range params {
-		if strings.HasPrefix(key, "query:") {
-			names = append(names, strings.TrimPrefix(key, "query:"))
+		if strings.HasPrefix(key, prefix) {
+			names = append(names, strings.TrimPrefix(key, prefix))
 		}
 	}
 	sort.Strings(names)
 
 	result := make([]RawParam, 0, len(names))
 	for _, name := range names {
-		param := params["query:"+name]
+		param := params[prefix+name]
 		if param == nil {
 			continue
 		}
@@ -216,6 +244,25 @@ func extractQueryParams(params map[string]*openapi3.Parameter) []RawParam {
 	return result
 }
 
+// ignoredHeaderParams are header parameters OpenAPI tells tools to ignore; they are owned by the request layer.
+var ignoredHeaderParams = map[string]struct{}{
+	"accept":        {},
+	"authorization": {},
+	"content-type":  {},
+}
+
+func extractHeaderParams(params map[string]*openapi3.Parameter) []RawParam {
+	headers := extractParamsIn(params, openapi3.ParameterInHeader)
+	result := make([]RawParam, 0, len(headers))
+	for _, header := range headers {
+		if _, ignored := ignoredHeaderParams[strings.ToLower(header.Name)]; ignored {
+			continue
+		}
+		result = append(result, header)
+	}
+	return result
+}
+
 func extractRequestBody(op *openapi3.Operation) (*RawBody, error) {
 	if op == nil || op.RequestBody == nil {
 		return nil, nil
@@ -240,18 +287,17 @@ func extractRequestBody(op *openapi3.Operation) (*RawBody, error) {
 	}, nil
 }
 
-func extractResponseSchema(op *openapi3.Operation) *openapi3.SchemaRef {
+func extractResponseSchema(op *openapi3.Operation) (string, *openapi3.SchemaRef) {
 	if op == nil || op.Responses == nil {
-		return nil
+		return "", nil
 	}
 
 	resp := pickResponse(op.Responses)
 	if resp == nil {
-		return nil
+		return "", nil
 	}
 
-	_, schemaRef := pickContentSchema(resp.Value.Content)
-	return schemaRef
+	return pickContentSchema(resp.Value.Content)
 }
 
 func pickResponse(responses *openapi3.Responses) *openapi3.ResponseRef {
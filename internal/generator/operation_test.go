@@ -57,3 +57,37 @@ func TestExtractOperations_SortsPathsBeforeMethods(t *testing.T) {
 		t.Fatalf("unexpected operation order: got %v want %v", gotOrder, wantOrder)
 	}
 }
+
+func TestExtractOperations_KeepsHeaderAndCookieParams(t *testing.T) {
+	doc := &openapi3.T{Paths: openapi3.NewPaths()}
+	doc.Paths.Set("/api/v1/orders", &openapi3.PathItem{
+		Post: &openapi3.Operation{
+			Parameters: openapi3.Parameters{
+				{Value: openapi3.NewHeaderParameter("X-Tenant-Id").WithRequired(true).WithSchema(openapi3.NewStringSchema())},
+				{Value: openapi3.NewHeaderParameter("Idempotency-Key").WithSchema(openapi3.NewStringSchema())},
+				{Value: openapi3.NewHeaderParameter("Authorization").WithSchema(openapi3.NewStringSchema())},
+				{Value: openapi3.NewCookieParameter("session").WithSchema(openapi3.NewStringSchema())},
+			},
+		},
+	})
+
+	ops, err := ExtractOperations(doc)
+	if err != nil {
+		t.Fatalf("ExtractOperations returned error: %v", err)
+	}
+	if len(ops) != 1 {
+		t.Fatalf("unexpected operation count: %d", len(ops))
+	}
+
+	headerNames := make([]string, 0, len(ops[0].HeaderParams))
+	for _, param := range ops[0].HeaderParams {
+		headerNames = append(headerNames, param.Name)
+	}
+	wantHeaders := []string{"Idempotency-Key", "X-Tenant-Id"}
+	if !reflect.DeepEqual(headerNames, wantHeaders) {
+		t.Fatalf("unexpected header params: got %v want %v", headerNames, wantHeaders)
+	}
+	if len(ops[0].CookieParams) != 1 || ops[0].CookieParams[0].Name != "session" {
+		t.Fatalf("unexpected cookie params: %+v", ops[0].CookieParams)
+	}
+}
@@ -0,0 +1,158 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeChanged ChangeKind = "changed"
+)
+
+// FileChange is a difference between the rendered output and the files on disk.
+// Path is relative to the output dir and uses forward slashes.
+type FileChange struct {
+	Path string
+	Kind ChangeKind
+}
+
+// outputFile is a rendered file, addressed relative to the output dir with forward slashes.
+type outputFile struct {
+	Path    string
+	Content string
+}
+
+// renderedOutput is everything a generation run would put on disk.
+type renderedOutput struct {
+	Dirs  []string
+	Files []outputFile
+}
+
+func (o *renderedOutput) addDir(path string) {
+	o.Dirs = append(o.Dirs, path)
+}
+
+func (o *renderedOutput) addFile(path string, content string) {
+	o.Files = append(o.Files, outputFile{Path: path, Content: content})
+}
+
+// write stores the rendered files and returns the files it added or changed plus the number of
+// unchanged files. Identical files are skipped so file watchers only see real changes, and each
+// write goes to a temp file that is renamed into place.
+func (o *renderedOutput) write(outputDir string) ([]FileChange, int, error) {
+	var (
+		changes   []FileChange
+		unchanged int
+	)
+	for _, dir := range o.Dirs {
+		if err := os.MkdirAll(filepath.Join(outputDir, filepath.FromSlash(dir)), 0o755); err != nil {
+			return nil, 0, fmt.Errorf("create dir %s failed: %w", dir, err)
+		}
+	}
+	for _, file := range o.Files {
+		target := filepath.Join(outputDir, filepath.FromSlash(file.Path))
+		kind := ChangeChanged
+		existing, err := os.ReadFile(target)
+		switch {
+		case err == nil && bytes.Equal(existing, []byte(file.Content)):
+			unchanged++
+			continue
+		case errors.Is(err, fs.ErrNotExist):
+			kind = ChangeAdded
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return nil, 0, fmt.Errorf("create dir for %s failed: %w", file.Path, err)
+		}
+		if err := writeFileAtomic(target, []byte(file.Content)); err != nil {
+			return nil, 0, fmt.Errorf("write %s failed: %w", file.Path, err)
+		}
+		changes = append(changes, FileChange{Path: file.Path, Kind: kind})
+	}
+	return changes, unchanged, nil
+}
+
+// writeFileAtomic writes data to a temp file next to target and renames it over target,
+// so readers never observe a partially written file.
+func writeFileAtomic(target string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, 0o644); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, target); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// diff compares the rendered files with the output dir without touching it. staleFiles are
+// the files a regular run would prune and are reported as removed.
+func (o *renderedOutput) diff(outputDir string, staleFiles []string) ([]FileChange, error) {
+	var changes []FileChange
+	for _, file := range o.Files {
+		existing, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(file.Path)))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			changes = append(changes, FileChange{Path: file.Path, Kind: ChangeAdded})
+		case err != nil:
+			return nil, fmt.Errorf("read %s failed: %w", file.Path, err)
+		case !bytes.Equal(existing, []byte(file.Content)):
+			changes = append(changes, FileChange{Path: file.Path, Kind: ChangeChanged})
+		}
+	}
+	return mergeChanges(changes, staleFiles), nil
+}
+
+// mergeChanges adds staleFiles as removed entries and sorts the result by path.
+func mergeChanges(changes []FileChange, staleFiles []string) []FileChange {
+	for _, path := range staleFiles {
+		changes = append(changes, FileChange{Path: path, Kind: ChangeRemoved})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+// listFiles returns the files under dir as slash paths relative to outputDir.
+func listFiles(outputDir string, dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(filepath.Join(outputDir, dir), func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(outputDir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	return files, err
+}
@@ -0,0 +1,357 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Extensions that replace the generated type of a schema or property.
+const (
+	// tsTypeExtension is a TypeScript type expression used verbatim, e.g. Record<string, JsonValue>.
+	tsTypeExtension = "x-ts-type"
+	// tsImportExtension is the module the names in x-ts-type are imported from, e.g. @/types/json.
+	tsImportExtension = "x-ts-import"
+)
+
+// rootModule is the module of the generated root index.ts.
+const rootModule = "@/api"
+
+// TypeOverride replaces the generated type of one schema.
+type TypeOverride struct {
+	// Type is a TypeScript type expression used verbatim.
+	Type string
+	// Import is the module the names in Type are imported from; empty means they are global.
+	Import string
+}
+
+// TypeImport is a type a generated file imports from another module.
+type TypeImport struct {
+	From string
+	Name string
+}
+
+var (
+	tsQuotedPattern = regexp.MustCompile(`'[^']*'|"[^"]*"|` + "`[^`]*`")
+	tsNamePattern   = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*`)
+	tsNumberPattern = regexp.MustCompile(`^[0-9][A-Za-z0-9_.]*`)
+)
+
+// tsBuiltinNames are identifiers of a type expression that never need an import.
+var tsBuiltinNames = map[string]struct{}{
+	"any": {}, "unknown": {}, "never": {}, "void": {}, "null": {}, "undefined": {}, "object": {},
+	"string": {}, "number": {}, "boolean": {}, "bigint": {}, "symbol": {}, "true": {}, "false": {},
+	"keyof": {}, "typeof": {}, "readonly": {}, "infer": {}, "extends": {}, "unique": {},
+	"in": {}, "is": {}, "as": {}, "asserts": {}, "new": {}, "abstract": {}, "this": {},
+	"Array": {}, "ReadonlyArray": {}, "Record": {}, "Partial": {}, "Required": {}, "Readonly": {},
+	"Pick": {}, "Omit": {}, "Exclude": {}, "Extract": {}, "NonNullable": {}, "Promise": {},
+	"Map": {}, "Set": {}, "Date": {}, "RegExp": {}, "Error": {}, "Blob": {}, "File": {}, "FormData": {},
+}
+
+// tsToken is an identifier or a single punctuation character of a type expression. String
+// literals become a single ' and number literals keep their text with ident unset.
+type tsToken struct {
+	text  string
+	ident bool
+}
+
+func tokenizeTSType(tsType string) []tsToken {
+	rest := tsQuotedPattern.ReplaceAllString(tsType, "'")
+	var tokens []tsToken
+	for rest != "" {
+		switch {
+		case strings.TrimLeft(rest, " \t\r\n") != rest:
+			rest = strings.TrimLeft(rest, " \t\r\n")
+		case tsNamePattern.MatchString(rest):
+			name := tsNamePattern.FindString(rest)
+			tokens = append(tokens, tsToken{text: name, ident: true})
+			rest = rest[len(name):]
+		case tsNumberPattern.MatchString(rest):
+			number := tsNumberPattern.FindString(rest)
+			tokens = append(tokens, tsToken{text: number})
+			rest = rest[len(number):]
+		default:
+			tokens = append(tokens, tsToken{text: rest[:1]})
+			rest = rest[1:]
+		}
+	}
+	return tokens
+}
+
+// importedNames returns the identifiers of a type expression that come from its import module.
+// Property keys, parameter and tuple labels, namespace members and type parameters are skipped,
+// so { label: string; value: JsonValue } imports only JsonValue.
+func importedNames(tsType string) []string {
+	tokens := tokenizeTSType(tsType)
+	params := typeParameterNames(tokens)
+	var names []string
+	for i, token := range tokens {
+		if !token.ident {
+			continue
+		}
+		if _, builtin := tsBuiltinNames[token.text]; builtin {
+			continue
+		}
+		if _, param := params[token.text]; param {
+			continue
+		}
+		if isMemberName(tokens, i) || isPropertyKey(tokens, i) {
+			continue
+		}
+		names = append(names, token.text)
+	}
+	return uniqueStrings(names)
+}
+
+// tokenAt returns the text of tokens[i], or "" outside the slice.
+func tokenAt(tokens []tsToken, i int) string {
+	if i < 0 || i >= len(tokens) {
+		return ""
+	}
+	return tokens[i].text
+}
+
+// isMemberName reports whether tokens[i] follows a dot of a qualified name such as NS.Type.
+// The spread of a tuple, ...Rest, is not a member access.
+func isMemberName(tokens []tsToken, i int) bool {
+	return tokenAt(tokens, i-1) == "." && i >= 2 && tokens[i-2].ident
+}
+
+// isPropertyKey reports whether tokens[i] names a property, method, parameter or tuple element:
+// it opens a member or list and is followed by a colon, an optional colon or a parameter list.
+func isPropertyKey(tokens []tsToken, i int) bool {
+	next := tokenAt(tokens, i+1)
+	if next == "?" && tokenAt(tokens, i+2) == ":" {
+		next = ":"
+	}
+	if next != ":" && next != "(" {
+		return false
+	}
+	switch tokenAt(tokens, i-1) {
+	case "", "{", ";", ",", "(", "[", ".", "readonly":
+		return true
+	}
+	return false
+}
+
+// typeParameterNames collects the names a type expression declares for itself: infer X, the key
+// of a mapped type [K in Keys], and the parameters of a generic function type <T>(value: T) => T.
+func typeParameterNames(tokens []tsToken) map[string]struct{} {
+	params := map[string]struct{}{}
+	for i, token := range tokens {
+		switch {
+		case token.text == "infer" && i+1 < len(tokens) && tokens[i+1].ident:
+			params[tokens[i+1].text] = struct{}{}
+		case token.text == "[" && i+2 < len(tokens) && tokens[i+1].ident && tokens[i+2].text == "in":
+			params[tokens[i+1].text] = struct{}{}
+		case token.text == "<" && (i == 0 || !tokens[i-1].ident):
+			for _, name := range genericParameterNames(tokens[i+1:]) {
+				params[name] = struct{}{}
+			}
+		}
+	}
+	return params
+}
+
+// genericParameterNames returns the names declared by a type parameter list, given the tokens
+// after its opening angle bracket.
+func genericParameterNames(tokens []tsToken) []string {
+	var names []string
+	depth := 0
+	for i, token := range tokens {
+		switch token.text {
+		case "<", "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+		case ">":
+			if depth == 0 {
+				return names
+			}
+			depth--
+		}
+		if depth == 0 && token.ident && (i == 0 || tokens[i-1].text == ",") {
+			names = append(names, token.text)
+		}
+	}
+	return names
+}
+
+// resolveTypeOverrides keys overrides by JSON pointer. Keys that are not pointers name a component
+// schema; Swagger 2 pointers under /definitions are moved to /components/schemas.
+func resolveTypeOverrides(overrides map[string]TypeOverride) map[string]TypeOverride {
+	if len(overrides) == 0 {
+		return nil
+	}
+	resolved := make(map[string]TypeOverride, len(overrides))
+	for key, override := range overrides {
+		resolved[typeOverridePointer(key)] = TypeOverride{Type: strings.TrimSpace(override.Type), Import: strings.TrimSpace(override.Import)}
+	}
+	return resolved
+}
+
+func typeOverridePointer(key string) string {
+	pointer := strings.TrimPrefix(strings.TrimSpace(key), "#")
+	if !strings.HasPrefix(pointer, "/") {
+		return "/components/schemas/" + escapeJSONPointer(pointer)
+	}
+	if rest, ok := strings.CutPrefix(pointer, "/definitions/"); ok {
+		return "/components/schemas/" + rest
+	}
+	return pointer
+}
+
+func validateTypeOverrides(overrides map[string]TypeOverride) error {
+	pointers := make([]string, 0, len(overrides))
+	for pointer := range overrides {
+		pointers = append(pointers, pointer)
+	}
+	sort.Strings(pointers)
+	for _, pointer := range pointers {
+		if overrides[pointer].Type == "" {
+			return fmt.Errorf("type override %s has an empty type", pointer)
+		}
+	}
+	return nil
+}
+
+// unusedTypeOverrides warns about override pointers that match no schema of any source.
+func unusedTypeOverrides(overrides map[string]TypeOverride, sources []Source) []string {
+	known := map[string]struct{}{}
+	for _, source := range sources {
+		for _, pointer := range indexSchemaPointers(source.Spec) {
+			known[pointer] = struct{}{}
+		}
+	}
+	var warnings []string
+	for pointer := range overrides {
+		if _, ok := known[pointer]; !ok {
+			warnings = append(warnings, fmt.Sprintf("type override %s matches no schema", pointer))
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+// invalidTypeExtensions warns about x-ts-type and x-ts-import values that are not strings, which
+// lookupOverride ignores.
+func invalidTypeExtensions(sources []Source) []string {
+	var warnings []string
+	for _, source := range sources {
+		for schemaRef, pointer := range indexSchemaPointers(source.Spec) {
+			if schemaRef.Ref != "" || schemaRef.Value == nil {
+				continue
+			}
+			for _, extension := range []string{tsTypeExtension, tsImportExtension} {
+				value, ok := schemaRef.Value.Extensions[extension]
+				if _, isString := value.(string); ok && !isString {
+					warnings = append(warnings, fmt.Sprintf("%s at %s is not a string and is ignored", extension, pointer))
+				}
+			}
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+// overrideType returns the type configured for schemaRef and records the names it imports.
+func (r *TypeRegistry) overrideType(schemaRef *openapi3.SchemaRef) (string, bool) {
+	override, ok := r.lookupOverride(schemaRef)
+	if !ok {
+		return "", false
+	}
+	r.recordImports(override.Import, importedNames(override.Type))
+	return override.Type, true
+}
+
+// lookupOverride finds the override of schemaRef, from the config overrides by JSON pointer or
+// from x-ts-type on an inline schema. A $ref defers to the override of its component.
+func (r *TypeRegistry) lookupOverride(schemaRef *openapi3.SchemaRef) (TypeOverride, bool) {
+	if schemaRef == nil {
+		return TypeOverride{}, false
+	}
+	if len(r.overrides) > 0 {
+		if r.schemaPointers == nil {
+			r.schemaPointers = indexSchemaPointers(r.doc)
+		}
+		if override, ok := r.overrides[r.schemaPointers[schemaRef]]; ok {
+			return override, true
+		}
+	}
+	if schemaRef.Ref != "" || schemaRef.Value == nil {
+		return TypeOverride{}, false
+	}
+	tsType, _ := schemaRef.Value.Extensions[tsTypeExtension].(string)
+	if tsType = strings.TrimSpace(tsType); tsType == "" {
+		return TypeOverride{}, false
+	}
+	module, _ := schemaRef.Value.Extensions[tsImportExtension].(string)
+	return TypeOverride{Type: tsType, Import: strings.TrimSpace(module)}, true
+}
+
+func (r *TypeRegistry) recordImports(module string, names []string) {
+	if module == "" || r.importSink == nil {
+		return
+	}
+	for _, name := range names {
+		r.importSink[TypeImport{From: module, Name: name}] = struct{}{}
+	}
+}
+
+// trackImports starts recording the types that need an import; the returned func stops and
+// returns them ordered by module and name.
+func (r *TypeRegistry) trackImports() func() []TypeImport {
+	r.importSink = map[TypeImport]struct{}{}
+	return func() []TypeImport {
+		imports := make([]TypeImport, 0, len(r.importSink))
+		for item := range r.importSink {
+			imports = append(imports, item)
+		}
+		r.importSink = nil
+		sortTypeImports(imports)
+		return imports
+	}
+}
+
+func sortTypeImports(imports []TypeImport) {
+	sort.Slice(imports, func(i, j int) bool {
+		if imports[i].From != imports[j].From {
+			return imports[i].From < imports[j].From
+		}
+		return imports[i].Name < imports[j].Name
+	})
+}
+
+// rootImportNames returns the names imported from the root index.
+func rootImportNames(imports []TypeImport) []string {
+	var names []string
+	for _, item := range imports {
+		if item.From == rootModule {
+			names = append(names, item.Name)
+		}
+	}
+	return names
+}
+
+// renderTypeImports writes one `import type` line per module other than the root index.
+func renderTypeImports(imports []TypeImport) string {
+	byModule := map[string][]string{}
+	for _, item := range imports {
+		if item.From != rootModule {
+			byModule[item.From] = append(byModule[item.From], item.Name)
+		}
+	}
+	modules := make([]string, 0, len(byModule))
+	for module := range byModule {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	var b strings.Builder
+	for _, module := range modules {
+		b.WriteString("import type { " + strings.Join(uniqueStrings(byModule[module]), ", ") + " } from '" + module + "';\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestRender_HonorsTSTypeExtension(t *testing.T) {
+	doc := buildSingleUserDoc("meta", "object")
+	user := doc.Components.Schemas["User"].Value
+	user.Properties["meta"].Value.Extensions = map[string]any{
+		"x-ts-type":   "Record<string, JsonValue>",
+		"x-ts-import": "@/types/json",
+	}
+
+	files, _, err := New(doc, Options{}).Render(context.Background())
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	model := files["users/model/index.ts"]
+	for _, want := range []string{
+		"import type { JsonValue } from '@/types/json';\n",
+		"  meta?: Record<string, JsonValue>;\n",
+	} {
+		if !strings.Contains(model, want) {
+			t.Fatalf("model is missing %q:\n%s", want, model)
+		}
+	}
+}
+
+func TestRender_AppliesTypeOverridesByNameAndPointer(t *testing.T) {
+	doc := buildSingleUserDoc("balance", "object")
+	doc.Components.Schemas["Money"] = &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type:       typesOf("object"),
+		Properties: openapi3.Schemas{"amount": {Value: &openapi3.Schema{Type: typesOf("integer")}}},
+	}}
+	doc.Components.Schemas["User"].Value.Properties["total"] = &openapi3.SchemaRef{Ref: "#/components/schemas/Money"}
+
+	overrides := map[string]TypeOverride{
+		"Money":                                 {Type: "string"},
+		"#/definitions/User/properties/balance": {Type: "Decimal", Import: "@/types/decimal"},
+		"Missing":                               {Type: "number"},
+	}
+	files, report, err := New(doc, Options{TypeOverrides: overrides}).Render(context.Background())
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	model := files["users/model/index.ts"]
+	for _, want := range []string{
+		"import type { Decimal } from '@/types/decimal';\n",
+		"  balance?: Decimal;\n",
+		"  total?: Money;\n",
+		"export type Money = string;\n",
+	} {
+		if !strings.Contains(model, want) {
+			t.Fatalf("model is missing %q:\n%s", want, model)
+		}
+	}
+	if len(report.Warnings) != 1 || !strings.Contains(report.Warnings[0], "/components/schemas/Missing") {
+		t.Fatalf("expected unused override warning, got %v", report.Warnings)
+	}
+}
+
+func TestImportedNames_SkipsBuiltinsAndLiterals(t *testing.T) {
+	got := importedNames("Record<string, JsonValue> | Array<'Draft' | Money> | null")
+	if strings.Join(got, ",") != "JsonValue,Money" {
+		t.Fatalf("unexpected imported names: %v", got)
+	}
+}
+
+func TestImportedNames_SkipsKeysAndTypeParameters(t *testing.T) {
+	cases := map[string]string{
+		"{ label: string; value?: JsonValue; readonly id: Id }": "Id,JsonValue",
+		"Array<{ label: string, value: Json }>":                 "Json",
+		"[first: Money, ...rest: Money[]]":                      "Money",
+		"(value: Input) => Output":                              "Input,Output",
+		"{ [K in Keys]: Field<K> }":                             "Field,Keys",
+		"T extends Array<infer Item> ? Item : Fallback":         "Fallback,T",
+		"<Value>(value: Value) => Wrapped<Value>":               "Wrapped",
+		"Money.Amount | 1e5":                                    "Money",
+		"{ get(key: Key): Value }":                              "Key,Value",
+	}
+	for expr, want := range cases {
+		if got := strings.Join(importedNames(expr), ","); got != want {
+			t.Fatalf("importedNames(%q) = %q, want %q", expr, got, want)
+		}
+	}
+}
+
+func TestRender_WarnsAboutNonStringTypeExtensions(t *testing.T) {
+	doc := buildSingleUserDoc("meta", "object")
+	doc.Components.Schemas["User"].Value.Properties["meta"].Value.Extensions = map[string]any{
+		"x-ts-type":   "Record<string, JsonValue>",
+		"x-ts-import": map[string]any{"from": "@/types/json"},
+	}
+
+	files, report, err := New(doc, Options{}).Render(context.Background())
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if strings.Contains(files["users/model/index.ts"], "@/types/json") {
+		t.Fatalf("a non-string x-ts-import should not be imported:\n%s", files["users/model/index.ts"])
+	}
+	want := "x-ts-import at /components/schemas/User/properties/meta is not a string and is ignored"
+	if len(report.Warnings) != 1 || report.Warnings[0] != want {
+		t.Fatalf("expected %q, got %v", want, report.Warnings)
+	}
+}
@@ -0,0 +1,456 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	PaginationKindPage   = "page"
+	PaginationKindCursor = "cursor"
+
+	defaultPaginationProfile = "fiberx"
+)
+
+// PaginationProfile describes one pagination convention: the request parameters that mark a paged query,
+// the response fields carrying the list and total (or next cursor), and the names of the emitted base types.
+type PaginationProfile struct {
+	Name string
+	// Kind is PaginationKindPage (list + total) or PaginationKindCursor (list + next cursor token).
+	Kind string
+	// Params are the query parameter names that must all be present for an operation to be paged.
+	Params []string
+	// CursorParam is the request parameter carrying the cursor token; it is typed as string.
+	CursorParam     string
+	ListField       string
+	TotalField      string
+	NextCursorField string
+	ParamType       string
+	ResultType      string
+}
+
+// PaginationRule selects a profile for operations under a path prefix.
+type PaginationRule struct {
+	PathPrefix string
+	Profile    string
+}
+
+// Pagination selects pagination profiles per spec (Default) or per path prefix (Rules).
+// Profiles with a built-in name override the built-in fields they set.
+type Pagination struct {
+	Default  string
+	Profiles []PaginationProfile
+	Rules    []PaginationRule
+}
+
+var fiberxPaginationProfile = PaginationProfile{
+	Name:       defaultPaginationProfile,
+	Kind:       PaginationKindPage,
+	Params:     []string{"current", "pageSize"},
+	ListField:  "list",
+	TotalField: "count",
+	ParamType:  "PageParam",
+	ResultType: "PageResult",
+}
+
+// BuiltinPaginationProfiles returns the profiles available without configuration.
+func BuiltinPaginationProfiles() []PaginationProfile {
+	return []PaginationProfile{
+		fiberxPaginationProfile,
+		{
+			Name:       "page",
+			Kind:       PaginationKindPage,
+			Params:     []string{"page", "size"},
+			ListField:  "items",
+			TotalField: "total",
+			ParamType:  "PageNumberParam",
+			ResultType: "PageNumberResult",
+		},
+		{
+			Name:       "offset",
+			Kind:       PaginationKindPage,
+			Params:     []string{"offset", "limit"},
+			ListField:  "items",
+			TotalField: "total",
+			ParamType:  "OffsetPageParam",
+			ResultType: "OffsetPageResult",
+		},
+		{
+			Name:            "cursor",
+			Kind:            PaginationKindCursor,
+			Params:          []string{"cursor", "limit"},
+			CursorParam:     "cursor",
+			ListField:       "items",
+			NextCursorField: "nextCursor",
+			ParamType:       "CursorPageParam",
+			ResultType:      "CursorPageResult",
+		},
+	}
+}
+
+func (p Pagination) profiles() map[string]PaginationProfile {
+	profiles := map[string]PaginationProfile{}
+	for _, profile := range BuiltinPaginationProfiles() {
+		profiles[profile.Name] = profile
+	}
+	for _, custom := range p.Profiles {
+		base, exists := profiles[custom.Name]
+		if !exists {
+			base = PaginationProfile{Name: custom.Name, Kind: custom.Kind}
+		}
+		profiles[custom.Name] = base.merge(custom)
+	}
+	return profiles
+}
+
+func (p Pagination) defaultName() string {
+	if name := strings.TrimSpace(p.Default); name != "" {
+		return name
+	}
+	return defaultPaginationProfile
+}
+
+func (p Pagination) validate() error {
+	profiles := p.profiles()
+	if _, ok := profiles[p.defaultName()]; !ok {
+		return fmt.Errorf("unknown default pagination profile: %s", p.defaultName())
+	}
+	for _, rule := range p.Rules {
+		if _, ok := profiles[rule.Profile]; !ok {
+			return fmt.Errorf("unknown pagination profile %s for path prefix %s", rule.Profile, rule.PathPrefix)
+		}
+	}
+	for _, name := range sortedProfileNames(profiles) {
+		profile := profiles[name]
+		if profile.Kind != PaginationKindPage && profile.Kind != PaginationKindCursor {
+			return fmt.Errorf("pagination profile %s has invalid kind %q", profile.Name, profile.Kind)
+		}
+		if len(profile.Params) == 0 || profile.ListField == "" || profile.ParamType == "" || profile.ResultType == "" {
+			return fmt.Errorf("pagination profile %s must define params, list field, param type and result type", profile.Name)
+		}
+		if strings.TrimSpace(profile.markerField()) == "" {
+			if profile.isCursor() {
+				return fmt.Errorf("pagination profile %s must define a next cursor field", profile.Name)
+			}
+			return fmt.Errorf("pagination profile %s must define a total field", profile.Name)
+		}
+	}
+	return p.validateTypeNames()
+}
+
+// validateTypeNames rejects active profiles that share a param or result type name without
+// sharing the other, since only one definition per name is emitted in the root index.
+func (p Pagination) validateTypeNames() error {
+	paramOwner := map[string]PaginationProfile{}
+	resultOwner := map[string]PaginationProfile{}
+	for _, profile := range p.activeProfiles() {
+		if owner, ok := paramOwner[profile.ParamType]; ok && owner.ResultType != profile.ResultType {
+			return fmt.Errorf("pagination profiles %s and %s share param type %s but use different result types", owner.Name, profile.Name, profile.ParamType)
+		}
+		if owner, ok := resultOwner[profile.ResultType]; ok && owner.ParamType != profile.ParamType {
+			return fmt.Errorf("pagination profiles %s and %s share result type %s but use different param types", owner.Name, profile.Name, profile.ResultType)
+		}
+		paramOwner[profile.ParamType] = profile
+		resultOwner[profile.ResultType] = profile
+	}
+	return nil
+}
+
+// profileFor returns the profile of the longest matching rule prefix, or the default profile.
+func (p Pagination) profileFor(path string) PaginationProfile {
+	profiles := p.profiles()
+	selected := p.defaultName()
+	longest := -1
+	for _, rule := range p.Rules {
+		if !strings.HasPrefix(path, rule.PathPrefix) || len(rule.PathPrefix) <= longest {
+			continue
+		}
+		if _, ok := profiles[rule.Profile]; !ok {
+			continue
+		}
+		selected = rule.Profile
+		longest = len(rule.PathPrefix)
+	}
+	if profile, ok := profiles[selected]; ok {
+		return profile
+	}
+	return fiberxPaginationProfile
+}
+
+// activeProfiles returns the default profile followed by rule profiles, deduplicated by emitted type names.
+func (p Pagination) activeProfiles() []PaginationProfile {
+	profiles := p.profiles()
+	names := []string{p.defaultName()}
+	var ruleNames []string
+	for _, rule := range p.Rules {
+		ruleNames = append(ruleNames, rule.Profile)
+	}
+	names = append(names, uniqueStrings(ruleNames)...)
+
+	seenNames := map[string]struct{}{}
+	seenTypes := map[[2]string]struct{}{}
+	var result []PaginationProfile
+	for _, name := range names {
+		profile, ok := profiles[name]
+		if !ok {
+			continue
+		}
+		if _, seen := seenNames[name]; seen {
+			continue
+		}
+		seenNames[name] = struct{}{}
+		typeNames := [2]string{profile.ParamType, profile.ResultType}
+		if _, seen := seenTypes[typeNames]; seen {
+			continue
+		}
+		seenTypes[typeNames] = struct{}{}
+		result = append(result, profile)
+	}
+	return result
+}
+
+func (p PaginationProfile) merge(override PaginationProfile) PaginationProfile {
+	merged := p
+	if override.Kind != "" {
+		merged.Kind = override.Kind
+	}
+	if len(override.Params) > 0 {
+		merged.Params = override.Params
+	}
+	if override.CursorParam != "" {
+		merged.CursorParam = override.CursorParam
+	}
+	if override.ListField != "" {
+		merged.ListField = override.ListField
+	}
+	if override.TotalField != "" {
+		merged.TotalField = override.TotalField
+	}
+	if override.NextCursorField != "" {
+		merged.NextCursorField = override.NextCursorField
+	}
+	if override.ParamType != "" {
+		merged.ParamType = override.ParamType
+	}
+	if override.ResultType != "" {
+		merged.ResultType = override.ResultType
+	}
+	if merged.Kind == "" {
+		merged.Kind = PaginationKindPage
+	}
+	return merged
+}
+
+func (p PaginationProfile) isCursor() bool {
+	return p.Kind == PaginationKindCursor
+}
+
+// markerField is the response field that, together with the list field, identifies a paged payload.
+func (p PaginationProfile) markerField() string {
+	if p.isCursor() {
+		return p.NextCursorField
+	}
+	return p.TotalField
+}
+
+func (p PaginationProfile) matchesQuery(params []RawParam) bool {
+	if len(p.Params) == 0 {
+		return false
+	}
+	present := map[string]struct{}{}
+	for _, param := range params {
+		present[param.Name] = struct{}{}
+	}
+	for _, name := range p.Params {
+		if _, ok := present[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// isParamName reports whether name is a paging parameter; a nil profile matches nothing.
+func (p *PaginationProfile) isParamName(name string) bool {
+	if p == nil {
+		return false
+	}
+	for _, param := range p.Params {
+		if param == name {
+			return true
+		}
+	}
+	return false
+}
+
+func pageReturnInfo(profile PaginationProfile, items *openapi3.SchemaRef, registry *TypeRegistry) (ReturnInfo, []string) {
+	itemType := registry.SchemaToType(items, nil)
+	used := collectTypeNamesFromSchema(items, registry)
+	return ReturnInfo{
+		Type:           profile.ResultType + "<" + itemType + ">",
+		UsesPageResult: true,
+		PageResultType: profile.ResultType,
+	}, used
+}
+
+type paginationShape struct {
+	ListSchema *openapi3.SchemaRef
+	HasMarker  bool
+}
+
+func extractPageListItems(schemaRef *openapi3.SchemaRef, registry *TypeRegistry, profile PaginationProfile) *openapi3.SchemaRef {
+	shape := collectPaginationShape(schemaRef, registry, profile, map[*openapi3.SchemaRef]struct{}{})
+	if !shape.HasMarker || shape.ListSchema == nil {
+		return nil
+	}
+	resolvedListSchema := derefSchemaRef(shape.ListSchema, registry)
+	if resolvedListSchema == nil || resolvedListSchema.Value == nil {
+		return nil
+	}
+	if resolvedListSchema.Value.Type == nil || !resolvedListSchema.Value.Type.Is("array") {
+		return nil
+	}
+	return resolvedListSchema.Value.Items
+}
+
+func collectPaginationShape(schemaRef *openapi3.SchemaRef, registry *TypeRegistry, profile PaginationProfile, visited map[*openapi3.SchemaRef]struct{}) paginationShape {
+	if schemaRef == nil {
+		return paginationShape{}
+	}
+	if _, ok := visited[schemaRef]; ok {
+		return paginationShape{}
+	}
+	visited[schemaRef] = struct{}{}
+
+	resolvedSchemaRef := derefSchemaRef(schemaRef, registry)
+	if resolvedSchemaRef == nil || resolvedSchemaRef.Value == nil {
+		return paginationShape{}
+	}
+
+	schema := resolvedSchemaRef.Value
+	shape := paginationShape{}
+
+	if schema.Properties != nil {
+		if marker := profile.markerField(); marker != "" {
+			if _, ok := schema.Properties[marker]; ok {
+				shape.HasMarker = true
+			}
+		}
+		shape.ListSchema = pickPreferredListSchema(shape.ListSchema, schema.Properties[profile.ListField], registry)
+	}
+
+	for _, part := range schema.AllOf {
+		partShape := collectPaginationShape(part, registry, profile, visited)
+		shape.HasMarker = shape.HasMarker || partShape.HasMarker
+		shape.ListSchema = pickPreferredListSchema(shape.ListSchema, partShape.ListSchema, registry)
+	}
+
+	return shape
+}
+
+func pickPreferredListSchema(current *openapi3.SchemaRef, candidate *openapi3.SchemaRef, registry *TypeRegistry) *openapi3.SchemaRef {
+	if candidate == nil {
+		return current
+	}
+	if current == nil {
+		return candidate
+	}
+	candidateIsArray := isArraySchema(candidate, registry)
+	currentIsArray := isArraySchema(current, registry)
+	if candidateIsArray && !currentIsArray {
+		return candidate
+	}
+	if candidateIsArray && currentIsArray {
+		return candidate
+	}
+	return current
+}
+
+func isArraySchema(schemaRef *openapi3.SchemaRef, registry *TypeRegistry) bool {
+	resolvedSchemaRef := derefSchemaRef(schemaRef, registry)
+	if resolvedSchemaRef == nil || resolvedSchemaRef.Value == nil {
+		return false
+	}
+	if resolvedSchemaRef.Value.Type == nil {
+		return false
+	}
+	return resolvedSchemaRef.Value.Type.Is("array")
+}
+
+var paginationFieldComments = map[string]string{
+	"current":    "当前页",
+	"page":       "当前页",
+	"pageNum":    "当前页",
+	"pageSize":   "每页数量",
+	"size":       "每页数量",
+	"limit":      "每页数量",
+	"offset":     "偏移量",
+	"cursor":     "分页游标",
+	"list":       "数据列表",
+	"items":      "数据列表",
+	"records":    "数据列表",
+	"count":      "总数量",
+	"total":      "总数量",
+	"nextCursor": "下一页游标",
+}
+
+func paginationFieldComment(name string, fallback string) string {
+	if comment, ok := paginationFieldComments[name]; ok {
+		return comment
+	}
+	return fallback
+}
+
+// renderPaginationTypes renders the PageParam/PageResult style base interfaces of the given profiles.
+func renderPaginationTypes(profiles []PaginationProfile) string {
+	var b strings.Builder
+	for idx, profile := range profiles {
+		if idx > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("/**\n * 分页查询参数\n */\n")
+		b.WriteString("export interface " + profile.ParamType + " {\n")
+		for _, name := range profile.Params {
+			paramType := "number"
+			if name == profile.CursorParam {
+				paramType = "string"
+			}
+			b.WriteString("  /** " + paginationFieldComment(name, "分页参数") + " */\n")
+			b.WriteString("  " + tsPropertyName(name) + "?: " + paramType + ";\n")
+		}
+		b.WriteString("}\n\n")
+
+		resultComment := "分页查询结果"
+		if profile.isCursor() {
+			resultComment = "游标分页查询结果"
+		}
+		b.WriteString("/**\n * " + resultComment + "\n */\n")
+		b.WriteString("export interface " + profile.ResultType + "<T> {\n")
+		b.WriteString("  /** " + paginationFieldComment(profile.ListField, "数据列表") + " */\n")
+		b.WriteString("  " + tsPropertyName(profile.ListField) + ": T[];\n")
+		if profile.isCursor() {
+			b.WriteString("  /** " + paginationFieldComment(profile.NextCursorField, "下一页游标") + " */\n")
+			b.WriteString("  " + tsPropertyName(profile.NextCursorField) + "?: string;\n")
+			if profile.TotalField != "" {
+				b.WriteString("  /** " + paginationFieldComment(profile.TotalField, "总数量") + " */\n")
+				b.WriteString("  " + tsPropertyName(profile.TotalField) + "?: number;\n")
+			}
+		} else {
+			b.WriteString("  /** " + paginationFieldComment(profile.TotalField, "总数量") + " */\n")
+			b.WriteString("  " + tsPropertyName(profile.TotalField) + ": number;\n")
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+func sortedProfileNames(profiles map[string]PaginationProfile) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -2,6 +2,7 @@ package generator
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -67,7 +68,7 @@ func TestResolveReturnType_RecognizesPaginationAllOfOverride(t *testing.T) {
 		},
 	}}
 
-	returnInfo, usedTypes := resolveReturnType("queryApis", responseSchema, registry, true)
+	returnInfo, usedTypes := resolveReturnType("queryApis", responseSchema, registry, fiberxPaginationProfile, true, Envelope{})
 	if returnInfo.Type != "PageResult<Api>" {
 		t.Fatalf("unexpected return type: got %s want %s", returnInfo.Type, "PageResult<Api>")
 	}
@@ -102,7 +103,7 @@ func TestResolveReturnType_DoesNotTreatAnyListAsPageResult(t *testing.T) {
 		},
 	}}
 
-	returnInfo, _ := resolveReturnType("queryAnything", responseSchema, registry, true)
+	returnInfo, _ := resolveReturnType("queryAnything", responseSchema, registry, fiberxPaginationProfile, true, Envelope{})
 	if returnInfo.UsesPageResult {
 		t.Fatal("expected UsesPageResult=false when list is untyped any")
 	}
@@ -110,3 +111,97 @@ func TestResolveReturnType_DoesNotTreatAnyListAsPageResult(t *testing.T) {
 		t.Fatalf("unexpected return type: got %s want %s", returnInfo.Type, "PaginationData")
 	}
 }
+
+func TestResolveReturnType_UsesCursorProfileFields(t *testing.T) {
+	registry := NewTypeRegistry(&openapi3.T{})
+	profile := Pagination{Default: "cursor"}.profileFor("/api/v1/events")
+
+	responseSchema := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type: typesOf("object"),
+		Properties: map[string]*openapi3.SchemaRef{
+			"data": {Value: &openapi3.Schema{
+				Type: typesOf("object"),
+				Properties: map[string]*openapi3.SchemaRef{
+					"nextCursor": {Value: &openapi3.Schema{Type: typesOf("string")}},
+					"items": {Value: &openapi3.Schema{
+						Type:  typesOf("array"),
+						Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: typesOf("string")}},
+					}},
+				},
+			}},
+		},
+	}}
+
+	returnInfo, _ := resolveReturnType("listEvents", responseSchema, registry, profile, false, Envelope{})
+	if returnInfo.Type != "CursorPageResult<string>" {
+		t.Fatalf("unexpected return type: got %s want %s", returnInfo.Type, "CursorPageResult<string>")
+	}
+	if returnInfo.PageResultType != "CursorPageResult" {
+		t.Fatalf("unexpected page result type: %s", returnInfo.PageResultType)
+	}
+}
+
+func TestPaginationProfileFor_SelectsLongestPathPrefix(t *testing.T) {
+	pagination := Pagination{
+		Rules: []PaginationRule{
+			{PathPrefix: "/api/v2", Profile: "page"},
+			{PathPrefix: "/api/v2/audit", Profile: "offset"},
+		},
+	}
+
+	if got := pagination.profileFor("/api/v1/users").Name; got != "fiberx" {
+		t.Fatalf("unexpected default profile: %s", got)
+	}
+	if got := pagination.profileFor("/api/v2/users").Name; got != "page" {
+		t.Fatalf("unexpected prefix profile: %s", got)
+	}
+	if got := pagination.profileFor("/api/v2/audit/logs").Name; got != "offset" {
+		t.Fatalf("unexpected longest prefix profile: %s", got)
+	}
+
+	page := pagination.profileFor("/api/v2/users")
+	if !page.matchesQuery([]RawParam{{Name: "page"}, {Name: "size"}, {Name: "keyword"}}) {
+		t.Fatal("page/size query should match the page profile")
+	}
+	if page.matchesQuery([]RawParam{{Name: "current"}, {Name: "pageSize"}}) {
+		t.Fatal("current/pageSize query should not match the page profile")
+	}
+}
+
+func TestRenderPaginationTypes_RendersCursorResult(t *testing.T) {
+	profiles := Pagination{Default: "cursor"}.activeProfiles()
+	content := renderPaginationTypes(profiles)
+	for _, want := range []string{
+		"export interface CursorPageParam {\n",
+		"  cursor?: string;\n",
+		"  limit?: number;\n",
+		"export interface CursorPageResult<T> {\n",
+		"  items: T[];\n",
+		"  nextCursor?: string;\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("pagination types missing %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestPaginationValidate_RequiresMarkerField(t *testing.T) {
+	page := Pagination{Profiles: []PaginationProfile{{Name: "legacy", Params: []string{"pageNo"}, ListField: "rows", ParamType: "LegacyParam", ResultType: "LegacyResult"}}}
+	if err := page.validate(); err == nil || !strings.Contains(err.Error(), "legacy must define a total field") {
+		t.Fatalf("expected total field error, got %v", err)
+	}
+	cursor := Pagination{Profiles: []PaginationProfile{{Name: "feed", Kind: PaginationKindCursor, Params: []string{"after"}, ListField: "items", ParamType: "FeedParam", ResultType: "FeedResult"}}}
+	if err := cursor.validate(); err == nil || !strings.Contains(err.Error(), "feed must define a next cursor field") {
+		t.Fatalf("expected next cursor field error, got %v", err)
+	}
+}
+
+func TestPaginationValidate_RejectsConflictingTypeNames(t *testing.T) {
+	pagination := Pagination{
+		Profiles: []PaginationProfile{{Name: "legacy", Params: []string{"pageNo"}, ListField: "rows", TotalField: "total", ParamType: "PageParam", ResultType: "LegacyResult"}},
+		Rules:    []PaginationRule{{PathPrefix: "/legacy", Profile: "legacy"}},
+	}
+	if err := pagination.validate(); err == nil || !strings.Contains(err.Error(), "share param type PageParam") {
+		t.Fatalf("expected conflicting type error, got %v", err)
+	}
+}
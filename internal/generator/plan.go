@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"sort"
+)
+
+// PlannedOperation describes how one operation will be emitted, without rendering any file.
+// Tooling such as lint uses it to report problems in generator terms.
+type PlannedOperation struct {
+	// Source is the index of the source that declares the operation.
+	Source int
+	Method string
+	Path   string
+	Group  string
+	// BaseName is the function name derived from the operationId or the path; Function is the
+	// emitted name, which differs when duplicates in the group are suffixed with 2, 3, ...
+	BaseName string
+	Function string
+	// EmptyData reports a success response whose envelope carries no data schema, so the
+	// function resolves to void.
+	EmptyData bool
+}
+
+// Plan groups and names the operations of every source the same way Generate does.
+// Operations are ordered by group, then in emission order.
+func (g *Generator) Plan() ([]PlannedOperation, error) {
+	if err := validateSources(g.sources); err != nil {
+		return nil, err
+	}
+	if err := g.grouping.validate(); err != nil {
+		return nil, err
+	}
+	_, groups, groupSources, err := g.groupOperations()
+	if err != nil {
+		return nil, err
+	}
+
+	groupNames := make([]string, 0, len(groups))
+	for name := range groups {
+		groupNames = append(groupNames, name)
+	}
+	sort.Strings(groupNames)
+
+	var planned []PlannedOperation
+	for _, groupName := range groupNames {
+		sourceIdx := groupSources[groupName]
+		registry := NewTypeRegistry(g.sources[sourceIdx].Spec)
+		var emitted []Operation
+		for _, raw := range groups[groupName] {
+			op := Operation{Name: ensureUniqueOperationName(raw.Name, emitted)}
+			emitted = append(emitted, op)
+
+			emptyData := false
+			if !raw.Download && !isEmptySchema(raw.Response) {
+				data := extractEnvelopeData(raw.Response, registry, g.envelope.forPath(raw.Path))
+				emptyData = data == nil || isEmptySchema(data)
+			}
+			planned = append(planned, PlannedOperation{
+				Source:    sourceIdx,
+				Method:    raw.Method,
+				Path:      raw.Path,
+				Group:     groupName,
+				BaseName:  raw.Name,
+				Function:  op.Name,
+				EmptyData: emptyData,
+			})
+		}
+	}
+	return planned, nil
+}
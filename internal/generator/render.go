@@ -8,8 +8,19 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
-func renderRootIndexFile() string {
-	return `/**
+func renderRootIndexFile(envelope Envelope, profiles []PaginationProfile) string {
+	resultInterface := fiberxResultInterface
+	if !envelope.isFiberx() {
+		resultInterface = envelope.renderResultInterface()
+	}
+	content := resultInterface + "\n" + renderApiErrorHelpers(envelope)
+	if len(profiles) == 0 {
+		return content
+	}
+	return content + "\n" + renderPaginationTypes(profiles)
+}
+
+const fiberxResultInterface = `/**
  * 接口统一返回结果
  */
 export interface ApiResult<T = any> {
@@ -26,34 +37,23 @@ export interface ApiResult<T = any> {
   /** 额外数据 */
   metadata?: Record<string, any>;
 }
-
-/**
- * 分页查询参数
- */
-export interface PageParam {
-  /** 当前页 */
-  current?: number;
-  /** 每页数量 */
-  pageSize?: number;
-}
-
-/**
- * 分页查询结果
- */
-export interface PageResult<T> {
-  /** 数据列表 */
-  list: T[];
-  /** 总数量 */
-  count: number;
-}
 `
-}
 
 func RenderType(def *TypeDef, registry *TypeRegistry) (string, []string) {
 	deps := map[string]struct{}{}
+	registry.warnScope = def.Name
+	registry.scope = def.origin
+	registry.direction = def.direction
+	defer func() {
+		registry.warnScope = ""
+		registry.scope = operationScope{}
+		registry.direction = directionNone
+	}()
 
 	schema := def.Schema
+	defer registry.enterSchema(schema)()
 	if schema == nil || schema.Value == nil && schema.Ref == "" {
+		registry.fallback(FallbackEmptySchema, "type has no schema")
 		content := fmt.Sprintf("export type %s = any;\n", def.Name)
 		return content, nil
 	}
@@ -69,6 +69,7 @@ func RenderType(def *TypeDef, registry *TypeRegistry) (string, []string) {
 		}
 	}
 
+	defer registry.enterSchema(resolved)()
 	content := renderTypeDefinition(def, resolved, registry, deps)
 
 	depList := make([]string, 0, len(deps))
@@ -85,6 +86,11 @@ func RenderType(def *TypeDef, registry *TypeRegistry) (string, []string) {
 
 func renderTypeDefinition(def *TypeDef, schemaRef *openapi3.SchemaRef, registry *TypeRegistry, deps map[string]struct{}) string {
 	if schemaRef == nil || schemaRef.Value == nil {
+		if schemaRef != nil && schemaRef.Ref != "" {
+			registry.fallback(FallbackUnresolvedRef, "schema ref not found: %s", schemaRef.Ref)
+		} else {
+			registry.fallback(FallbackEmptySchema, "type has no schema")
+		}
 		return fmt.Sprintf("export type %s = any;\n", def.Name)
 	}
 
@@ -94,43 +100,90 @@ func renderTypeDefinition(def *TypeDef, schemaRef *openapi3.SchemaRef, registry
 		description = strings.TrimSpace(schema.Description)
 	}
 
-	isObject := (schema.Type != nil && schema.Type.Is("object")) || len(schema.Properties) > 0 || schema.AdditionalProperties.Schema != nil || schema.AdditionalProperties.Has != nil
-	if len(schema.Enum) > 0 || len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 || len(schema.AllOf) > 0 || !isObject {
+	if def.omitFrom != "" {
+		deps[def.omitFrom] = struct{}{}
+		return formatTypeAlias(def.Name, omitType(def), "")
+	}
+	if tsType, ok := registry.overrideType(schemaRef); ok {
+		return formatTypeAlias(def.Name, tsType, description)
+	}
+
+	if enumSchema := registry.namedEnumSchema(def); enumSchema != nil {
+		if content, ok := renderEnum(def, enumSchema, registry.enumStyle, description); ok {
+			return content
+		}
+	}
+
+	if !rendersAsInterface(schema) {
 		typeExpr := registry.schemaValueToType(schema, deps)
 		if schema.Nullable {
 			typeExpr = typeExpr + " | null"
 		}
-		return formatTypeAlias(def.Name, typeExpr, description)
+		return formatTypeAlias(def.Name, typeExpr, description) + registry.renderTypeGuards(def)
 	}
 
+	if def.objectAlias {
+		return formatObjectAlias(def.Name, schema, registry, deps, description)
+	}
 	return formatInterface(def.Name, schema, registry, deps, description, def.Extends)
 }
 
+// rendersAsInterface reports whether a named schema is emitted as an interface rather than a type alias.
+func rendersAsInterface(schema *openapi3.Schema) bool {
+	isObject := (schema.Type != nil && schema.Type.Is("object")) || len(schema.Properties) > 0 || schema.AdditionalProperties.Schema != nil || schema.AdditionalProperties.Has != nil
+	return isObject && len(schema.Enum) == 0 && len(schema.OneOf) == 0 && len(schema.AnyOf) == 0 && len(schema.AllOf) == 0
+}
+
+// escapeJSDoc keeps free text from closing the comment it is written into.
+func escapeJSDoc(text string) string {
+	return strings.ReplaceAll(text, "*/", "*\\/")
+}
+
 func formatTypeAlias(name string, expr string, description string) string {
 	var b strings.Builder
 	if description != "" {
-		b.WriteString("/** " + description + " */\n")
+		b.WriteString("/** " + escapeJSDoc(description) + " */\n")
 	}
 	b.WriteString("export type " + name + " = " + expr + ";\n")
 	return b.String()
 }
 
 func formatInterface(name string, schema *openapi3.Schema, registry *TypeRegistry, deps map[string]struct{}, description string, extends []string) string {
-	required := resolveRequiredFields(name, schema, registry)
-	keys := resolvePropertyOrder(name, schema, registry)
-
 	var b strings.Builder
 	if description != "" {
-		b.WriteString("/** " + description + " */\n")
+		b.WriteString("/** " + escapeJSDoc(description) + " */\n")
 	}
 	extendClause := ""
 	if len(extends) > 0 {
 		extendClause = " extends " + strings.Join(extends, ", ")
 	}
 	b.WriteString("export interface " + name + extendClause + " {\n")
+	b.WriteString(formatObjectMembers(name, schema, registry, deps))
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// formatObjectAlias renders an object schema as `export type Name = { ... };`.
+func formatObjectAlias(name string, schema *openapi3.Schema, registry *TypeRegistry, deps map[string]struct{}, description string) string {
+	var b strings.Builder
+	if description != "" {
+		b.WriteString("/** " + escapeJSDoc(description) + " */\n")
+	}
+	b.WriteString("export type " + name + " = {\n")
+	b.WriteString(formatObjectMembers(name, schema, registry, deps))
+	b.WriteString("};\n")
+	return b.String()
+}
+
+// formatObjectMembers renders the property lines shared by interfaces and object aliases.
+func formatObjectMembers(name string, schema *openapi3.Schema, registry *TypeRegistry, deps map[string]struct{}) string {
+	required := resolveRequiredFields(name, schema, registry)
+	keys := resolvePropertyOrder(name, schema, registry)
+
+	var b strings.Builder
 	for _, key := range keys {
 		propSchema := schema.Properties[key]
-		if propSchema == nil {
+		if propSchema == nil || registry.direction.omitsProperty(propSchema) {
 			continue
 		}
 		optional := "?"
@@ -146,7 +199,7 @@ func formatInterface(name string, schema *openapi3.Schema, registry *TypeRegistr
 			propDesc = strings.TrimSpace(propSchema.Value.Description)
 		}
 		if propDesc != "" {
-			b.WriteString("  /** " + propDesc + " */\n")
+			b.WriteString("  /** " + escapeJSDoc(propDesc) + " */\n")
 		}
 		propType := registry.SchemaToType(propSchema, deps)
 		b.WriteString("  " + propName + optional + ": " + propType + ";\n")
@@ -158,8 +211,6 @@ func formatInterface(name string, schema *openapi3.Schema, registry *TypeRegistr
 	} else if len(schema.Properties) == 0 && schema.AdditionalProperties.Has != nil && *schema.AdditionalProperties.Has {
 		b.WriteString("  [key: string]: any;\n")
 	}
-
-	b.WriteString("}\n")
 	return b.String()
 }
 
@@ -302,207 +353,12 @@ func RenderModelIndex(typeNames []string) string {
 	return b.String()
 }
 
-func RenderAPIFile(ops []Operation, modelImports []string, usesPageResult bool) string {
-	var b strings.Builder
-	b.WriteString("import request from '@/utils/request';\n")
-
-	apiImports := []string{"ApiResult"}
-	if usesPageResult {
-		apiImports = append(apiImports, "PageResult")
-	}
-	b.WriteString("import type { " + strings.Join(apiImports, ", ") + " } from '@/api';\n")
-
-	if len(modelImports) > 0 {
-		sort.Strings(modelImports)
-		b.WriteString("import type {\n")
-		lastIndex := len(modelImports) - 1
-		for idx, name := range modelImports {
-			lineSuffix := ",\n"
-			if idx == lastIndex {
-				lineSuffix = "\n"
-			}
-			b.WriteString("  " + name + lineSuffix)
-		}
-		b.WriteString("} from './model';\n")
-	}
-
-	b.WriteString("\n")
-
-	for idx, op := range ops {
-		if idx > 0 {
-			b.WriteString("\n")
-		}
-		b.WriteString(RenderOperation(op))
-		b.WriteString("\n")
-	}
-
-	return b.String()
-}
-
-func RenderOperation(op Operation) string {
-	var b strings.Builder
-	summary := strings.TrimSpace(op.Summary)
-	if summary == "" {
-		summary = op.Name
-	}
-
-	b.WriteString("/**\n")
-	b.WriteString(" * " + summary + "\n")
-	for _, param := range op.PathParams {
-		if param.Description == "" {
-			b.WriteString(" * @param " + param.VarName + " - 路径参数\n")
-		} else {
-			b.WriteString(" * @param " + param.VarName + " - " + param.Description + "\n")
-		}
-	}
-	if op.Body != nil {
-		b.WriteString(" * @param data - 请求数据\n")
-	}
-	if op.Query != nil {
-		b.WriteString(" * @param params - 查询参数\n")
-	}
-	b.WriteString(" * @returns Promise<" + op.Return.Type + ">\n")
-	b.WriteString(" */\n")
-
-	args := renderOperationArgs(op)
-	b.WriteString("export async function " + op.Name + "(" + args + ") {\n")
-
-	url := renderPathTemplate(op)
-	if op.Body != nil && op.Body.IsForm {
-		b.WriteString("  const formData = new FormData();\n")
-		b.WriteString("  if (data) {\n")
-		b.WriteString("    for (const [key, value] of Object.entries(data)) {\n")
-		b.WriteString("      if (value === undefined || value === null) {\n")
-		b.WriteString("        continue;\n")
-		b.WriteString("      }\n")
-		b.WriteString("      if (Array.isArray(value)) {\n")
-		b.WriteString("        for (const item of value) {\n")
-		b.WriteString("          if (item !== undefined && item !== null) {\n")
-		b.WriteString("            formData.append(key, item as any);\n")
-		b.WriteString("          }\n")
-		b.WriteString("        }\n")
-		b.WriteString("      } else {\n")
-		b.WriteString("        formData.append(key, value as any);\n")
-		b.WriteString("      }\n")
-		b.WriteString("    }\n")
-		b.WriteString("  }\n")
-	}
-
-	reqLine := renderRequest(op, url)
-	b.WriteString(reqLine)
-
-	if op.Return.IsVoid {
-		b.WriteString("  if (res.data.success) {\n")
-		b.WriteString("    return;\n")
-		b.WriteString("  }\n")
-		b.WriteString("  return Promise.reject(new Error(res.data?.message ?? '")
-		b.WriteString(escapeSingleQuotes(op.ErrorText))
-		b.WriteString("'));\n")
-	} else {
-		b.WriteString("  if (res.data.success && res.data.data !== undefined) {\n")
-		b.WriteString("    return res.data.data;\n")
-		b.WriteString("  }\n")
-		b.WriteString("  return Promise.reject(new Error(res.data?.message ?? '")
-		b.WriteString(escapeSingleQuotes(op.ErrorText))
-		b.WriteString("'));\n")
-	}
-
-	b.WriteString("}\n")
-
-	return b.String()
-}
-
-func renderOperationArgs(op Operation) string {
-	var args []string
-	for _, param := range op.PathParams {
-		name := sanitizeIdentifier(param.VarName)
-		arg := name + ": " + param.Type
-		if !param.Required {
-			arg = name + "?: " + param.Type
-		}
-		args = append(args, arg)
-	}
-	if op.Body != nil {
-		if op.Body.Optional {
-			args = append(args, "data?: "+op.Body.TypeName)
-		} else {
-			args = append(args, "data: "+op.Body.TypeName)
-		}
-	}
-	if op.Query != nil {
-		if op.Query.Optional {
-			args = append(args, "params?: "+op.Query.TypeName)
-		} else {
-			args = append(args, "params: "+op.Query.TypeName)
-		}
-	}
-
-	return strings.Join(args, ", ")
-}
-
-func renderPathTemplate(op Operation) string {
-	if len(op.PathParams) == 0 {
-		return "'" + escapeSingleQuotes(op.Path) + "'"
-	}
-	path := op.Path
-	for _, param := range op.PathParams {
-		placeholder := "{" + param.Name + "}"
-		path = strings.ReplaceAll(path, placeholder, "${"+param.VarName+"}")
-	}
-	return "`" + path + "`"
-}
-
-func renderRequest(op Operation, url string) string {
-	method := strings.ToLower(op.Method)
-	returnType := op.Return.Type
-
-	if op.Body != nil && op.Body.IsForm {
-		config := buildConfigObject(op, false, true)
-		if config == "" {
-			return fmt.Sprintf("  const res = await request.%s<ApiResult<%s>>(%s, formData);\n", method, returnType, url)
-		}
-		return fmt.Sprintf("  const res = await request.%s<ApiResult<%s>>(%s, formData, %s);\n", method, returnType, url, config)
-	}
-
-	if op.Body != nil {
-		if method == "delete" {
-			config := buildConfigObject(op, true, false)
-			if config == "" {
-				return fmt.Sprintf("  const res = await request.delete<ApiResult<%s>>(%s);\n", returnType, url)
-			}
-			return fmt.Sprintf("  const res = await request.delete<ApiResult<%s>>(%s, %s);\n", returnType, url, config)
-		}
-
-		config := buildConfigObject(op, false, false)
-		if config == "" {
-			return fmt.Sprintf("  const res = await request.%s<ApiResult<%s>>(%s, data);\n", method, returnType, url)
-		}
-		return fmt.Sprintf("  const res = await request.%s<ApiResult<%s>>(%s, data, %s);\n", method, returnType, url, config)
-	}
-
-	if op.Query != nil {
-		config := buildConfigObject(op, false, false)
-		return fmt.Sprintf("  const res = await request.%s<ApiResult<%s>>(%s, %s);\n", method, returnType, url, config)
-	}
-
-	return fmt.Sprintf("  const res = await request.%s<ApiResult<%s>>(%s);\n", method, returnType, url)
-}
-
-func buildConfigObject(op Operation, includeData bool, includeFormHeader bool) string {
-	var entries []string
-	if includeData {
-		entries = append(entries, "data")
-	}
-	if op.Query != nil {
-		entries = append(entries, "params")
-	}
-	if includeFormHeader {
-		entries = append(entries, "headers: { 'Content-Type': 'multipart/form-data' }")
-	}
-	if len(entries) == 0 {
-		return ""
+// tsPropertyName quotes property keys that are not valid identifiers.
+func tsPropertyName(name string) string {
+	if isValidIdentifier(name) {
+		return name
 	}
-	return "{ " + strings.Join(entries, ", ") + " }"
+	return "'" + escapeTSString(name) + "'"
 }
 
 func escapeSingleQuotes(value string) string {
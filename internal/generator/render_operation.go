@@ -0,0 +1,244 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+func RenderOperation(op Operation) string {
+	var b strings.Builder
+	summary := strings.TrimSpace(op.Summary)
+	if summary == "" {
+		summary = op.Name
+	}
+
+	b.WriteString(renderErrorUnion(op))
+	b.WriteString("/**\n")
+	b.WriteString(" * " + escapeJSDoc(summary) + "\n")
+	for _, param := range op.PathParams {
+		if param.Description == "" {
+			b.WriteString(" * @param " + param.VarName + " - 路径参数\n")
+		} else {
+			b.WriteString(" * @param " + param.VarName + " - " + escapeJSDoc(param.Description) + "\n")
+		}
+	}
+	if op.Body != nil {
+		b.WriteString(" * @param data - 请求数据\n")
+	}
+	if op.Query != nil {
+		b.WriteString(" * @param params - 查询参数\n")
+	}
+	if op.Headers != nil {
+		b.WriteString(" * @param headers - 请求头\n")
+	}
+	for _, cookie := range op.Cookies {
+		description := cookie.Description
+		if description == "" {
+			description = "Cookie 参数"
+		}
+		b.WriteString(" * Cookie " + cookie.Name + " - " + escapeJSDoc(description) + "（由浏览器自动携带）\n")
+	}
+	b.WriteString(" * @returns Promise<" + op.Return.Type + ">\n")
+	if op.ErrorType != "" {
+		b.WriteString(" * @throws {" + op.ErrorType + "}\n")
+	}
+	b.WriteString(" */\n")
+
+	args := renderOperationArgs(op)
+	b.WriteString("export async function " + op.Name + "(" + args + ") {\n")
+
+	url := renderPathTemplate(op)
+	if op.Body != nil && op.Body.IsForm {
+		b.WriteString("  const formData = new FormData();\n")
+		b.WriteString("  if (data) {\n")
+		b.WriteString("    for (const [key, value] of Object.entries(data)) {\n")
+		b.WriteString("      if (value === undefined || value === null) {\n")
+		b.WriteString("        continue;\n")
+		b.WriteString("      }\n")
+		b.WriteString("      if (Array.isArray(value)) {\n")
+		b.WriteString("        for (const item of value) {\n")
+		b.WriteString("          if (item !== undefined && item !== null) {\n")
+		b.WriteString("            formData.append(key, item as any);\n")
+		b.WriteString("          }\n")
+		b.WriteString("        }\n")
+		b.WriteString("      } else {\n")
+		b.WriteString("        formData.append(key, value as any);\n")
+		b.WriteString("      }\n")
+		b.WriteString("    }\n")
+		b.WriteString("  }\n")
+	}
+
+	call := renderAwaitedRequest(op, url)
+	if op.Return.IsDownload {
+		b.WriteString("  const res = await " + call)
+		b.WriteString("  return toDownloadResult(res.data, res.headers);\n")
+		b.WriteString("}\n")
+		return b.String()
+	}
+	if op.Envelope.Unwrapped {
+		if op.Return.IsVoid {
+			b.WriteString("  await " + call)
+		} else {
+			b.WriteString("  const res = await " + call)
+			b.WriteString("  return res.data;\n")
+		}
+		b.WriteString("}\n")
+		return b.String()
+	}
+
+	b.WriteString("  const res = await " + call)
+	success := op.Envelope.successExpr("res.data")
+	if op.Return.IsVoid {
+		b.WriteString("  if (" + success + ") {\n")
+		b.WriteString("    return;\n")
+		b.WriteString("  }\n")
+	} else {
+		data := op.Envelope.dataExpr("res.data")
+		b.WriteString("  if (" + success + " && " + data + " !== undefined) {\n")
+		b.WriteString("    return " + data + ";\n")
+		b.WriteString("  }\n")
+	}
+	b.WriteString("  return Promise.reject(\n")
+	b.WriteString("    new ApiError(res.status, " + op.Envelope.reasonExpr("res.data") + ", res.data, " + op.Envelope.messageExpr("res.data") + " ?? '")
+	b.WriteString(escapeSingleQuotes(op.ErrorText))
+	b.WriteString("'),\n")
+	b.WriteString("  );\n")
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// operationArg is one parameter of a generated API function, in signature order.
+type operationArg struct {
+	name     string
+	typeName string
+	optional bool
+}
+
+func operationArgs(op Operation) []operationArg {
+	var args []operationArg
+	for _, param := range op.PathParams {
+		args = append(args, operationArg{name: sanitizeIdentifier(param.VarName), typeName: param.Type, optional: !param.Required})
+	}
+	if op.Body != nil {
+		args = append(args, operationArg{name: "data", typeName: op.Body.TypeName, optional: op.Body.Optional})
+	}
+	if op.Query != nil {
+		args = append(args, operationArg{name: "params", typeName: op.Query.TypeName, optional: op.Query.Optional})
+	}
+	if op.Headers != nil {
+		args = append(args, operationArg{name: "headers", typeName: op.Headers.TypeName, optional: op.Headers.Optional})
+	}
+	return args
+}
+
+func renderOperationArgs(op Operation) string {
+	args := operationArgs(op)
+
+	// An optional parameter cannot precede a required one in TS; such arguments accept undefined instead.
+	lastRequired := -1
+	for idx, arg := range args {
+		if !arg.optional {
+			lastRequired = idx
+		}
+	}
+
+	rendered := make([]string, 0, len(args))
+	for idx, arg := range args {
+		switch {
+		case !arg.optional:
+			rendered = append(rendered, arg.name+": "+arg.typeName)
+		case idx < lastRequired:
+			rendered = append(rendered, arg.name+": "+arg.typeName+" | undefined")
+		default:
+			rendered = append(rendered, arg.name+"?: "+arg.typeName)
+		}
+	}
+
+	return strings.Join(rendered, ", ")
+}
+
+func renderPathTemplate(op Operation) string {
+	path := op.Path
+	if op.URL != "" {
+		path = op.URL
+	}
+	if len(op.PathParams) == 0 {
+		return "'" + escapeSingleQuotes(path) + "'"
+	}
+	for _, param := range op.PathParams {
+		placeholder := "{" + param.Name + "}"
+		path = strings.ReplaceAll(path, placeholder, "${"+param.VarName+"}")
+	}
+	return "`" + path + "`"
+}
+
+// renderRequest renders the request call expression, e.g. request.get<ApiResult<T>>(url, { params }).
+func renderRequest(op Operation, url string) string {
+	method := strings.ToLower(op.Method)
+	responseType := "ApiResult<" + op.Return.Type + ">"
+	switch {
+	case op.Return.IsDownload:
+		responseType = "Blob"
+	case op.Envelope.Unwrapped:
+		responseType = op.Return.Type
+	}
+
+	args := []string{url}
+	switch {
+	case op.Body != nil && op.Body.IsForm:
+		args = append(args, "formData")
+		if config := buildConfigObject(op, false, true); config != "" {
+			args = append(args, config)
+		}
+	case op.Body != nil && method == "delete":
+		if config := buildConfigObject(op, true, false); config != "" {
+			args = append(args, config)
+		}
+	case op.Body != nil:
+		args = append(args, "data")
+		if config := buildConfigObject(op, false, false); config != "" {
+			args = append(args, config)
+		}
+	default:
+		if config := buildConfigObject(op, false, false); config != "" {
+			args = append(args, config)
+		}
+	}
+
+	return fmt.Sprintf("request.%s<%s>(%s)", method, responseType, strings.Join(args, ", "))
+}
+
+// renderAwaitedRequest renders the request call with transport failures mapped to ApiError, ending in ";\n".
+func renderAwaitedRequest(op Operation, url string) string {
+	call := strings.TrimPrefix(renderRequest(op, url), "request.")
+	return "request\n" +
+		"    ." + call + "\n" +
+		"    .catch((error) => Promise.reject(toApiError(error, '" + escapeSingleQuotes(op.ErrorText) + "')));\n"
+}
+
+func buildConfigObject(op Operation, includeData bool, includeFormHeader bool) string {
+	var entries []string
+	if includeData {
+		entries = append(entries, "data")
+	}
+	if op.Query != nil {
+		entries = append(entries, "params")
+	}
+	switch {
+	case includeFormHeader && op.Headers != nil:
+		entries = append(entries, "headers: { ...headers, 'Content-Type': 'multipart/form-data' }")
+	case includeFormHeader:
+		entries = append(entries, "headers: { 'Content-Type': 'multipart/form-data' }")
+	case op.Headers != nil:
+		entries = append(entries, "headers")
+	}
+	if op.Return.IsDownload {
+		entries = append(entries, "responseType: 'blob'")
+	}
+	if len(entries) == 0 {
+		return ""
+	}
+	return "{ " + strings.Join(entries, ", ") + " }"
+}
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -8,7 +9,7 @@ import (
 )
 
 func TestRenderAPIFile_ModelImportsWithoutTrailingComma(t *testing.T) {
-	content := RenderAPIFile(nil, []string{"SendEmailCodeForm", "Captcha", "GetCaptchaContentParam"}, false)
+	content := RenderAPIFile(nil, []string{"SendEmailCodeForm", "Captcha", "GetCaptchaContentParam"})
 
 	expectedImportBlock := "import type {\n  Captcha,\n  GetCaptchaContentParam,\n  SendEmailCodeForm\n} from './model';\n"
 	if !strings.Contains(content, expectedImportBlock) {
@@ -22,7 +23,7 @@ func TestRenderAPIFile_ModelImportsWithoutTrailingComma(t *testing.T) {
 }
 
 func TestRenderAPIFile_SingleModelImportWithoutTrailingComma(t *testing.T) {
-	content := RenderAPIFile(nil, []string{"LoginForm"}, false)
+	content := RenderAPIFile(nil, []string{"LoginForm"})
 
 	expectedImportBlock := "import type {\n  LoginForm\n} from './model';\n"
 	if !strings.Contains(content, expectedImportBlock) {
@@ -138,3 +139,70 @@ func TestRenderType_UsesGoFieldOrderWhenMatched(t *testing.T) {
 		t.Fatalf("unexpected field order, expected method -> path -> tag:\n%s", content)
 	}
 }
+
+func TestRenderOperation_MergesTypedHeadersIntoConfig(t *testing.T) {
+	op := Operation{
+		Name:      "createOrder",
+		Method:    "post",
+		Path:      "/api/v1/orders",
+		Query:     &QueryInfo{TypeName: "CreateOrderParam", Optional: true},
+		Headers:   &HeaderInfo{TypeName: "CreateOrderHeaders"},
+		Body:      &BodyInfo{TypeName: "OrderForm"},
+		Return:    ReturnInfo{Type: "void", IsVoid: true},
+		ErrorText: "创建订单失败",
+	}
+
+	content := RenderOperation(op)
+	if !strings.Contains(content, "export async function createOrder(data: OrderForm, params: CreateOrderParam | undefined, headers: CreateOrderHeaders) {") {
+		t.Fatalf("required headers should be enforced in the signature:\n%s", content)
+	}
+	if !strings.Contains(content, ".post<ApiResult<void>>('/api/v1/orders', data, { params, headers })\n") {
+		t.Fatalf("headers should be merged into the request config:\n%s", content)
+	}
+}
+
+func TestRender_HeaderParamsRenderAsRecordCompatibleAlias(t *testing.T) {
+	doc := &openapi3.T{Paths: openapi3.NewPaths()}
+	doc.Paths.Set("/api/v1/orders", &openapi3.PathItem{Post: &openapi3.Operation{
+		Summary: "创建订单",
+		Parameters: openapi3.Parameters{
+			{Value: openapi3.NewHeaderParameter("X-Tenant-Id").WithRequired(true).WithSchema(openapi3.NewStringSchema())},
+			{Value: openapi3.NewHeaderParameter("Idempotency-Key").WithSchema(openapi3.NewStringSchema())},
+		},
+	}})
+
+	files, _, err := New(doc, Options{}).Render(context.Background())
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	model := files["orders/model/index.ts"]
+	want := "export type PostApiV1OrdersHeaders = {\n  'Idempotency-Key'?: string;\n  'X-Tenant-Id': string;\n};\n"
+	if !strings.Contains(model, want) {
+		t.Fatalf("headers should be an object alias, which axios accepts as RawAxiosRequestHeaders:\n%s", model)
+	}
+	if strings.Contains(model, "export interface PostApiV1OrdersHeaders") {
+		t.Fatalf("an interface has no index signature and is not assignable to axios headers:\n%s", model)
+	}
+	if !strings.Contains(files["orders/index.ts"], "('/api/v1/orders', { headers })") {
+		t.Fatalf("headers should be passed as the request config headers:\n%s", files["orders/index.ts"])
+	}
+}
+
+func TestRenderOperation_MergesHeadersWithFormContentType(t *testing.T) {
+	op := Operation{
+		Name:    "uploadFile",
+		Method:  "post",
+		Path:    "/api/v1/files",
+		Headers: &HeaderInfo{TypeName: "UploadFileHeaders", Optional: true},
+		Body:    &BodyInfo{TypeName: "UploadFileBody", IsForm: true},
+		Return:  ReturnInfo{Type: "void", IsVoid: true},
+	}
+
+	content := RenderOperation(op)
+	if !strings.Contains(content, "headers?: UploadFileHeaders)") {
+		t.Fatalf("optional headers should stay optional:\n%s", content)
+	}
+	if !strings.Contains(content, "{ headers: { ...headers, 'Content-Type': 'multipart/form-data' } }") {
+		t.Fatalf("form content type should be merged with headers:\n%s", content)
+	}
+}
@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// scalarHelperTypes are the shared types a format can map to by name. The ones in use are
+// emitted in the root index.ts and imported from '@/api'. The optional brand property keeps plain
+// strings assignable while documenting the format in signatures.
+var scalarHelperTypes = map[string]string{
+	"DateTimeString": "/** ISO 8601 日期时间字符串，如 2006-01-02T15:04:05Z */\nexport type DateTimeString = string & { readonly __format?: 'date-time' };\n",
+	"DateString":     "/** ISO 8601 日期字符串，如 2006-01-02 */\nexport type DateString = string & { readonly __format?: 'date' };\n",
+	"UUID":           "/** UUID 字符串 */\nexport type UUID = string & { readonly __format?: 'uuid' };\n",
+	"DecimalString":  "/** 十进制数字字符串，保留精度 */\nexport type DecimalString = string & { readonly __format?: 'decimal' };\n",
+}
+
+// DefaultScalarFormats returns the format mapping used when Options.Scalars does not override it.
+// decimal maps to a string because decimal libraries serialize it as one to keep precision.
+// Integer formats keep number because encoding/json writes int64 as a JSON number; map int64 to
+// string only when the backend serializes it as a string.
+func DefaultScalarFormats() map[string]string {
+	return map[string]string{
+		"date-time": "DateTimeString",
+		"date":      "DateString",
+		"uuid":      "UUID",
+		"decimal":   "DecimalString",
+	}
+}
+
+// resolveScalarFormats merges overrides into the defaults.
+func resolveScalarFormats(overrides map[string]string) map[string]string {
+	formats := DefaultScalarFormats()
+	for format, tsType := range overrides {
+		formats[strings.TrimSpace(format)] = strings.TrimSpace(tsType)
+	}
+	return formats
+}
+
+func validateScalarFormats(formats map[string]string) error {
+	keys := make([]string, 0, len(formats))
+	for format := range formats {
+		keys = append(keys, format)
+	}
+	sort.Strings(keys)
+	for _, format := range keys {
+		if format == "" {
+			return fmt.Errorf("scalar format mapping has an empty format")
+		}
+		if formats[format] == "" {
+			return fmt.Errorf("scalar format %q maps to an empty type", format)
+		}
+	}
+	return nil
+}
+
+// scalarType returns the TypeScript type configured for a string or number format. Shared helper
+// types are recorded as imports from the root index.
+func (r *TypeRegistry) scalarType(format string) (string, bool) {
+	if format == "" {
+		return "", false
+	}
+	tsType, ok := r.scalars[format]
+	if !ok {
+		return "", false
+	}
+	if _, helper := scalarHelperTypes[tsType]; helper {
+		r.recordImports(rootModule, []string{tsType})
+	}
+	return tsType, true
+}
+
+// renderScalarHelpers emits the definitions of the helper types in use, in name order.
+func renderScalarHelpers(names []string) string {
+	var b strings.Builder
+	for _, name := range names {
+		if definition, ok := scalarHelperTypes[name]; ok {
+			b.WriteString("\n" + definition)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,59 @@
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestRender_MapsFormatsToScalarTypes(t *testing.T) {
+	doc := buildSingleUserDoc("createdAt", "string")
+	user := doc.Components.Schemas["User"].Value
+	user.Properties["createdAt"].Value.Format = "date-time"
+	user.Properties["id"] = &openapi3.SchemaRef{Value: &openapi3.Schema{Type: typesOf("integer"), Format: "int64"}}
+	user.Properties["price"] = &openapi3.SchemaRef{Value: &openapi3.Schema{Type: typesOf("string"), Format: "decimal"}}
+	get := doc.Paths.Value("/api/v1/users").Get
+	get.Parameters = openapi3.Parameters{
+		{Value: &openapi3.Parameter{Name: "id", In: "path", Required: true, Schema: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: typesOf("string"), Format: "uuid"}}}},
+	}
+	doc.Paths = openapi3.NewPaths(openapi3.WithPath("/api/v1/users/{id}", &openapi3.PathItem{Get: get}))
+
+	files, _, err := New(doc, Options{Scalars: map[string]string{"int64": "string"}}).Render(context.Background())
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	model := files["users/model/index.ts"]
+	for _, want := range []string{
+		"import type { DateTimeString, DecimalString } from '@/api';\n",
+		"  createdAt?: DateTimeString;\n",
+		"  id?: string;\n",
+		"  price?: DecimalString;\n",
+	} {
+		if !strings.Contains(model, want) {
+			t.Fatalf("model is missing %q:\n%s", want, model)
+		}
+	}
+	api := files["users/index.ts"]
+	if !strings.Contains(api, "import type { ApiResult, UUID } from '@/api';\n") || !strings.Contains(api, "(id: UUID)") {
+		t.Fatalf("path param should use UUID:\n%s", api)
+	}
+	root := files["index.ts"]
+	for _, name := range []string{"DateTimeString", "DecimalString", "UUID"} {
+		if !strings.Contains(root, "export type "+name+" = string & ") {
+			t.Fatalf("root index should define %s:\n%s", name, root)
+		}
+	}
+	if strings.Contains(root, "DateString =") {
+		t.Fatalf("unused helper types should not be emitted:\n%s", root)
+	}
+}
+
+func TestGenerate_RejectsEmptyScalarMapping(t *testing.T) {
+	_, _, err := New(buildSingleUserDoc("id", "integer"), Options{Scalars: map[string]string{"int64": " "}}).Render(context.Background())
+	if err == nil || !strings.Contains(err.Error(), `"int64"`) {
+		t.Fatalf("expected empty mapping error, got %v", err)
+	}
+}
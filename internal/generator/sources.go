@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Source is one spec merged into a generation run.
+type Source struct {
+	Spec *openapi3.T
+	// Name identifies the source in errors and logs, usually the input path or URL.
+	Name string
+	// Namespace prefixes every group of the source: namespace "iam" turns group "users" into "iamUsers".
+	Namespace string
+	// BaseURL is prepended to the request URL of every operation, e.g. "/iam" or "https://iam.example.com".
+	BaseURL string
+}
+
+func (s Source) validate(idx int) error {
+	if s.Spec == nil {
+		return fmt.Errorf("source %s: spec is nil", s.label(idx))
+	}
+	if s.Namespace != "" && !isGroupNamespace(s.Namespace) {
+		return fmt.Errorf("source %s has invalid namespace %q: use ASCII letters and digits starting with a letter", s.label(idx), s.Namespace)
+	}
+	return nil
+}
+
+func (s Source) label(idx int) string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return fmt.Sprintf("#%d", idx+1)
+}
+
+// groupName applies the namespace prefix to a group directory name.
+func (s Source) groupName(group string) string {
+	if s.Namespace == "" {
+		return group
+	}
+	return lowerFirst(s.Namespace) + upperFirst(group)
+}
+
+// requestPath joins the base URL and the operation path without doubling the slash.
+func (s Source) requestPath(path string) string {
+	if s.BaseURL == "" {
+		return path
+	}
+	return strings.TrimRight(s.BaseURL, "/") + path
+}
+
+func isGroupNamespace(namespace string) bool {
+	for idx, r := range namespace {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case idx > 0 && r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+	return namespace != ""
+}
+
+// validateSources checks each source and that namespaces are unique.
+func validateSources(sources []Source) error {
+	if len(sources) == 0 {
+		return fmt.Errorf("spec is nil")
+	}
+	namespaces := map[string]int{}
+	for idx, source := range sources {
+		if err := source.validate(idx); err != nil {
+			return err
+		}
+		if source.Namespace == "" {
+			continue
+		}
+		key := strings.ToLower(source.Namespace)
+		if prev, ok := namespaces[key]; ok {
+			return fmt.Errorf("sources %s and %s share namespace %q", sources[prev].label(prev), source.label(idx), source.Namespace)
+		}
+		namespaces[key] = idx
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestGenerate_MergesSourcesWithNamespacesAndBaseURLs(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "api")
+	sources := []Source{
+		{Spec: buildSingleUserDoc("id", "string"), Name: "sys.json"},
+		{Spec: buildSingleUserDoc("uid", "integer"), Name: "iam.json", Namespace: "iam", BaseURL: "/iam/"},
+	}
+	report, err := NewFromSources(sources, Options{OutputDir: outputDir}).Generate()
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if report.Groups != 2 || report.Operations != 2 {
+		t.Fatalf("unexpected report: %+v", *report)
+	}
+
+	sysModel := readGeneratedFile(t, outputDir, "users/model/index.ts")
+	iamModel := readGeneratedFile(t, outputDir, "iamUsers/model/index.ts")
+	if !strings.Contains(sysModel, "id?: string;") || !strings.Contains(iamModel, "uid?: number;") {
+		t.Fatalf("each source should keep its own User shape\n--- users ---\n%s\n--- iamUsers ---\n%s", sysModel, iamModel)
+	}
+	if strings.Contains(iamModel, "User2") {
+		t.Fatalf("same-named components of different sources should not be renamed:\n%s", iamModel)
+	}
+
+	iamAPI := readGeneratedFile(t, outputDir, "iamUsers/index.ts")
+	if !strings.Contains(iamAPI, "'/iam/api/v1/users'") {
+		t.Fatalf("base URL should prefix request URLs:\n%s", iamAPI)
+	}
+}
+
+func TestGenerate_RejectsGroupsProducedByTwoSources(t *testing.T) {
+	sources := []Source{
+		{Spec: buildSingleUserDoc("id", "string"), Name: "sys.json"},
+		{Spec: buildSingleUserDoc("uid", "integer"), Name: "iam.json"},
+	}
+	_, err := NewFromSources(sources, Options{OutputDir: t.TempDir(), Check: true}).Generate()
+	if err == nil || !strings.Contains(err.Error(), `group "users" is produced by both sys.json and iam.json`) {
+		t.Fatalf("expected group collision error, got %v", err)
+	}
+
+	sources[0].Namespace, sources[1].Namespace = "iam", "IAM"
+	_, err = NewFromSources(sources, Options{OutputDir: t.TempDir(), Check: true}).Generate()
+	if err == nil || !strings.Contains(err.Error(), "share namespace") {
+		t.Fatalf("expected duplicate namespace error, got %v", err)
+	}
+}
+
+func buildSingleUserDoc(field string, fieldType string) *openapi3.T {
+	components := openapi3.NewComponents()
+	components.Schemas = openapi3.Schemas{
+		"User": {Value: &openapi3.Schema{
+			Type:       typesOf("object"),
+			Properties: openapi3.Schemas{field: {Value: &openapi3.Schema{Type: typesOf(fieldType)}}},
+		}},
+	}
+	response := openapi3.NewResponse().
+		WithDescription("ok").
+		WithContent(openapi3.NewContentWithJSONSchemaRef(&openapi3.SchemaRef{Value: &openapi3.Schema{
+			Type:       typesOf("object"),
+			Properties: openapi3.Schemas{"data": {Ref: "#/components/schemas/User"}},
+		}}))
+
+	doc := &openapi3.T{Components: &components, Paths: openapi3.NewPaths()}
+	doc.Paths.Set("/api/v1/users", &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Summary:   "查询用户",
+			Responses: openapi3.NewResponses(openapi3.WithStatus(200, &openapi3.ResponseRef{Value: response})),
+		},
+	})
+	return doc
+}
+
+func readGeneratedFile(t *testing.T, outputDir string, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatalf("read %s failed: %v", name, err)
+	}
+	return string(content)
+}
@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gopkg-dev/swagger-ts-gen/internal/surface"
+)
+
+// Surface builds the functions and types Generate would emit, without rendering any file.
+// Functions are ordered by group and emission order, types by group and name.
+func (g *Generator) Surface() (*surface.Surface, error) {
+	if err := g.prepare(); err != nil {
+		return nil, err
+	}
+	_, groups, groupSources, err := g.groupOperations()
+	if err != nil {
+		return nil, err
+	}
+
+	groupNames := make([]string, 0, len(groups))
+	for name := range groups {
+		groupNames = append(groupNames, name)
+	}
+	sort.Strings(groupNames)
+
+	result := &surface.Surface{Functions: []surface.Function{}, Types: []surface.Type{}}
+	for _, groupName := range groupNames {
+		typedOps, _, registry, err := g.buildGroupOperations(g.sources[groupSources[groupName]], groups[groupName])
+		if err != nil {
+			return nil, err
+		}
+		expandRegistryReferences(registry)
+		describeGroup(result, groupName, typedOps, registry)
+	}
+	return result, nil
+}
+
+// describeGroup appends the functions and types of one group; the registry must already be expanded.
+func describeGroup(result *surface.Surface, groupName string, typedOps []Operation, registry *TypeRegistry) {
+	for _, op := range typedOps {
+		result.Functions = append(result.Functions, describeFunction(groupName, op))
+	}
+	for _, def := range registry.Types() {
+		result.Types = append(result.Types, describeType(groupName, def, registry))
+	}
+}
+
+func describeFunction(group string, op Operation) surface.Function {
+	function := surface.Function{
+		Group:   group,
+		Name:    op.Name,
+		Method:  strings.ToUpper(op.Method),
+		Path:    op.Path,
+		Returns: op.Return.Type,
+	}
+	for _, arg := range operationArgs(op) {
+		function.Args = append(function.Args, surface.Field{Name: arg.name, Type: arg.typeName, Optional: arg.optional})
+	}
+	return function
+}
+
+// describeType mirrors RenderType: the same schemas become interfaces, with the same
+// required fields, and everything else an alias.
+func describeType(group string, def *TypeDef, registry *TypeRegistry) surface.Type {
+	described := surface.Type{Group: group, Name: def.Name, Kind: surface.Alias, Extends: def.Extends}
+	if def.omitFrom != "" {
+		described.Alias = omitType(def)
+		return described
+	}
+	registry.direction = def.direction
+	defer func() { registry.direction = directionNone }()
+
+	schemaRef := def.Schema
+	if schemaRef != nil && schemaRef.Ref != "" {
+		if resolved := registry.resolveRefSchema(schemaRef.Ref); resolved != nil {
+			schemaRef = resolved
+		}
+	}
+	if schemaRef == nil || schemaRef.Value == nil {
+		described.Alias = "any"
+		return described
+	}
+
+	if tsType, ok := registry.overrideType(schemaRef); ok {
+		described.Alias = tsType
+		return described
+	}
+
+	schema := schemaRef.Value
+	if !rendersAsInterface(schema) {
+		described.Alias = registry.schemaValueToType(schema, nil)
+		if schema.Nullable {
+			described.Alias += " | null"
+		}
+		return described
+	}
+
+	described.Kind = surface.Interface
+	required := resolveRequiredFields(def.Name, schema, registry)
+	for _, key := range resolvePropertyOrder(def.Name, schema, registry) {
+		propSchema := schema.Properties[key]
+		if propSchema == nil || registry.direction.omitsProperty(propSchema) {
+			continue
+		}
+		_, isRequired := required[key]
+		described.Fields = append(described.Fields, surface.Field{Name: key, Type: registry.SchemaToType(propSchema, nil), Optional: !isRequired})
+	}
+	if len(schema.Properties) == 0 && schema.AdditionalProperties.Schema != nil {
+		described.Fields = append(described.Fields, surface.Field{Name: surface.IndexSignature, Type: registry.SchemaToType(schema.AdditionalProperties.Schema, nil)})
+	} else if len(schema.Properties) == 0 && schema.AdditionalProperties.Has != nil && *schema.AdditionalProperties.Has {
+		described.Fields = append(described.Fields, surface.Field{Name: surface.IndexSignature, Type: "any"})
+	}
+	return described
+}
@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/gopkg-dev/swagger-ts-gen/internal/surface"
+)
+
+func TestSurface_DescribesFunctionsAndTypes(t *testing.T) {
+	doc := buildSingleUserDoc("age", "integer")
+	doc.Paths.Value("/api/v1/users").Get.Parameters = openapi3.Parameters{
+		{Value: openapi3.NewQueryParameter("keyword").WithSchema(openapi3.NewStringSchema())},
+	}
+
+	api, err := New(doc, Options{}).Surface()
+	if err != nil {
+		t.Fatalf("Surface returned error: %v", err)
+	}
+
+	wantFunctions := []surface.Function{{
+		Group:   "users",
+		Name:    "getApiV1Users",
+		Method:  "GET",
+		Path:    "/api/v1/users",
+		Args:    []surface.Field{{Name: "params", Type: "GetApiV1UsersParam", Optional: true}},
+		Returns: "User",
+	}}
+	if !reflect.DeepEqual(api.Functions, wantFunctions) {
+		t.Fatalf("unexpected functions:\n%+v", api.Functions)
+	}
+	wantTypes := []surface.Type{
+		{Group: "users", Name: "GetApiV1UsersParam", Kind: surface.Interface, Fields: []surface.Field{{Name: "keyword", Type: "string", Optional: true}}},
+		{Group: "users", Name: "User", Kind: surface.Interface, Fields: []surface.Field{{Name: "age", Type: "number", Optional: true}}},
+	}
+	if !reflect.DeepEqual(api.Types, wantTypes) {
+		t.Fatalf("unexpected types:\n%+v", api.Types)
+	}
+}
@@ -14,6 +14,16 @@ type TypeDef struct {
 	Description string
 	Kind        string
 	Extends     []string
+	// origin is the operation that registered an inline type; empty for components.
+	origin operationScope
+	// direction is the way an inline type travels; its properties that never do are left out.
+	direction schemaDirection
+	// omitFrom and omit describe a type derived from a component without some properties.
+	omitFrom string
+	omit     []string
+	// objectAlias renders an object as `type X = { ... }` rather than an interface. Unlike an
+	// interface, the alias is assignable to Record types such as axios' RawAxiosRequestHeaders.
+	objectAlias bool
 }
 
 type TypeRegistry struct {
@@ -23,6 +33,30 @@ type TypeRegistry struct {
 	refToName            map[string]string
 	typeOrder            []string
 	optionalFieldsByType map[string][]GoStructOptionality
+	prefixItemsCache     map[*openapi3.Schema]openapi3.SchemaRefs
+	// warnScope names the type being rendered so warnings can point at it.
+	warnScope string
+	warnings  map[string]struct{}
+	// scope is the operation being built; schemaStack and schemaPointers locate any fallbacks.
+	scope          operationScope
+	schemaStack    []*openapi3.SchemaRef
+	schemaPointers map[*openapi3.SchemaRef]string
+	// direction is set while building a request body or response; directedNames caches the
+	// types derived for it by component ref and direction.
+	direction     schemaDirection
+	directedNames map[string]string
+	fallbacks     []Fallback
+	fallbackSeen  map[Fallback]struct{}
+	// enumStyle is one of the EnumStyle constants; empty means EnumStyleConst.
+	enumStyle string
+	// typeGuards emits a type guard per variant of named discriminated unions.
+	typeGuards bool
+	// scalars maps string and number formats to TypeScript types; overrides replaces the type
+	// of schemas by JSON pointer.
+	scalars   map[string]string
+	overrides map[string]TypeOverride
+	// importSink, when set, collects the types that the file being built imports.
+	importSink map[TypeImport]struct{}
 }
 
 func NewTypeRegistry(doc *openapi3.T) *TypeRegistry {
@@ -32,6 +66,10 @@ func NewTypeRegistry(doc *openapi3.T) *TypeRegistry {
 		nameTaken:            map[string]bool{},
 		refToName:            map[string]string{},
 		optionalFieldsByType: map[string][]GoStructOptionality{},
+		prefixItemsCache:     map[*openapi3.Schema]openapi3.SchemaRefs{},
+		warnings:             map[string]struct{}{},
+		fallbackSeen:         map[Fallback]struct{}{},
+		directedNames:        map[string]string{},
 	}
 }
 
@@ -83,10 +121,20 @@ func (r *TypeRegistry) RegisterInline(nameHint string, schema *openapi3.SchemaRe
 		Schema:      schema,
 		Description: description,
 		Kind:        "inline",
+		origin:      r.scope,
+		direction:   r.direction,
 	})
 	return name
 }
 
+// registerInlineAlias registers an inline object type that renders as a type alias, for values
+// passed where a Record is expected, such as the headers of a request config.
+func (r *TypeRegistry) registerInlineAlias(nameHint string, schema *openapi3.SchemaRef) string {
+	name := r.RegisterInline(nameHint, schema, "")
+	r.types[name].objectAlias = true
+	return name
+}
+
 func (r *TypeRegistry) RegisterInlineWithExtends(nameHint string, schema *openapi3.SchemaRef, description string, extends []string) string {
 	base := sanitizeTypeName(nameHint)
 	name := r.ensureUniqueName(base)
@@ -96,6 +144,8 @@ func (r *TypeRegistry) RegisterInlineWithExtends(nameHint string, schema *openap
 		Description: description,
 		Kind:        "inline",
 		Extends:     uniqueStrings(extends),
+		origin:      r.scope,
+		direction:   r.direction,
 	})
 	return name
 }
@@ -135,6 +185,8 @@ func (r *TypeRegistry) addType(def *TypeDef) {
 	r.typeOrder = append(r.typeOrder, def.Name)
 }
 
+// resolveRefSchema looks up local component refs only; the loader internalizes external
+// refs (./schemas/user.yaml) into components before generation.
 func (r *TypeRegistry) resolveRefSchema(ref string) *openapi3.SchemaRef {
 	if r.doc == nil || r.doc.Components == nil {
 		return nil
@@ -185,11 +237,17 @@ func simplifyTypeName(raw string) string {
 
 func (r *TypeRegistry) SchemaToType(schemaRef *openapi3.SchemaRef, deps map[string]struct{}) string {
 	if schemaRef == nil {
+		r.fallback(FallbackEmptySchema, "missing schema")
 		return "any"
 	}
+	defer r.enterSchema(schemaRef)()
+	if tsType, ok := r.overrideType(schemaRef); ok {
+		return tsType
+	}
 	if schemaRef.Ref != "" {
-		name, err := r.RegisterRef(schemaRef.Ref)
+		name, err := r.directedRef(schemaRef.Ref)
 		if err != nil {
+			r.fallback(FallbackUnresolvedRef, "%v", err)
 			return "any"
 		}
 		if deps != nil {
@@ -200,6 +258,7 @@ func (r *TypeRegistry) SchemaToType(schemaRef *openapi3.SchemaRef, deps map[stri
 
 	schema := schemaRef.Value
 	if schema == nil {
+		r.fallback(FallbackEmptySchema, "schema has no value")
 		return "any"
 	}
 
@@ -214,33 +273,58 @@ func (r *TypeRegistry) schemaValueToType(schema *openapi3.Schema, deps map[strin
 	if schema == nil {
 		return "any"
 	}
+	r.warnUnsupportedKeywords(schema)
 
+	if literal, ok := constType(schema); ok {
+		return literal
+	}
 	if len(schema.Enum) > 0 {
 		return enumToType(schema.Enum)
 	}
 
 	if len(schema.OneOf) > 0 {
+		if union, ok := r.discriminatedUnion(schema, schema.OneOf, deps); ok {
+			return union
+		}
 		return joinSchemaTypes(r, schema.OneOf, deps, " | ")
 	}
 	if len(schema.AnyOf) > 0 {
+		if union, ok := r.discriminatedUnion(schema, schema.AnyOf, deps); ok {
+			return union
+		}
 		return joinSchemaTypes(r, schema.AnyOf, deps, " | ")
 	}
 	if len(schema.AllOf) > 0 {
 		return joinSchemaTypes(r, schema.AllOf, deps, " & ")
 	}
+	if schema.Type != nil && len(schema.Type.Slice()) > 1 {
+		return r.multiTypeUnion(schema, deps)
+	}
+	prefixItems := r.prefixItems(schema)
 
 	switch {
 	case schema.Type != nil && schema.Type.Is("string"):
 		if schema.Format == "binary" {
 			return "Blob"
 		}
+		if tsType, ok := r.scalarType(schema.Format); ok {
+			return tsType
+		}
 		return "string"
 	case schema.Type != nil && (schema.Type.Is("integer") || schema.Type.Is("number")):
+		if tsType, ok := r.scalarType(schema.Format); ok {
+			return tsType
+		}
 		return "number"
 	case schema.Type != nil && schema.Type.Is("boolean"):
 		return "boolean"
+	case schema.Type != nil && schema.Type.Is(openapi3.TypeNull):
+		return "null"
+	case len(prefixItems) > 0 && (schema.Type == nil || schema.Type.Is("array")):
+		return r.tupleType(schema, prefixItems, deps)
 	case schema.Type != nil && schema.Type.Is("array"):
 		if schema.Items == nil {
+			r.fallback(FallbackEmptySchema, "array schema has no items")
 			return "Array<any>"
 		}
 		itemType := r.SchemaToType(schema.Items, deps)
@@ -253,6 +337,7 @@ func (r *TypeRegistry) schemaValueToType(schema *openapi3.Schema, deps map[strin
 		}
 	}
 
+	r.fallback(FallbackEmptySchema, "schema has no type")
 	return "any"
 }
 
@@ -288,7 +373,7 @@ func renderInlineObject(r *TypeRegistry, schema *openapi3.Schema, deps map[strin
 	b.WriteString("{\n")
 	for _, name := range keys {
 		propSchema := schema.Properties[name]
-		if propSchema == nil {
+		if propSchema == nil || r.direction.omitsProperty(propSchema) {
 			continue
 		}
 		optional := "?"
@@ -310,18 +395,7 @@ func renderInlineObject(r *TypeRegistry, schema *openapi3.Schema, deps map[strin
 func enumToType(values []any) string {
 	var parts []string
 	for _, v := range values {
-		switch val := v.(type) {
-		case string:
-			parts = append(parts, fmt.Sprintf("'%s'", escapeTSString(val)))
-		case float64:
-			parts = append(parts, fmt.Sprintf("%v", val))
-		case int:
-			parts = append(parts, fmt.Sprintf("%d", val))
-		case bool:
-			parts = append(parts, fmt.Sprintf("%t", val))
-		default:
-			parts = append(parts, "any")
-		}
+		parts = append(parts, enumLiteral(v))
 	}
 	if len(parts) == 0 {
 		return "any"
@@ -329,6 +403,24 @@ func enumToType(values []any) string {
 	return strings.Join(parts, " | ")
 }
 
+// enumLiteral renders one enum value as a TypeScript literal.
+func enumLiteral(value any) string {
+	switch val := value.(type) {
+	case string:
+		return fmt.Sprintf("'%s'", escapeTSString(val))
+	case float64:
+		return fmt.Sprintf("%v", val)
+	case int:
+		return fmt.Sprintf("%d", val)
+	case bool:
+		return fmt.Sprintf("%t", val)
+	case nil:
+		return "null"
+	default:
+		return "any"
+	}
+}
+
 func escapeTSString(value string) string {
 	if value == "" {
 		return value
@@ -0,0 +1,239 @@
+package generator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// unionVariant is one member of a oneOf/anyOf with a discriminator.
+type unionVariant struct {
+	// Type is the member type, intersected with the discriminator literal when the variant
+	// does not declare it.
+	Type string
+	// Name is the variant's type name, or the discriminator value for inline variants.
+	Name string
+	// Literals are the discriminator values that select the variant.
+	Literals []string
+}
+
+// discriminatedUnion renders a oneOf/anyOf that has discriminator.propertyName so TypeScript can
+// narrow on it. Variant values come from discriminator.mapping, or from the component name of a
+// $ref variant, as the OpenAPI spec defines for implicit mappings.
+func (r *TypeRegistry) discriminatedUnion(schema *openapi3.Schema, refs openapi3.SchemaRefs, deps map[string]struct{}) (string, bool) {
+	variants, ok := r.unionVariants(schema, refs, deps)
+	if !ok {
+		return "", false
+	}
+	parts := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		parts = append(parts, variant.Type)
+	}
+	return strings.Join(parts, " | "), true
+}
+
+func (r *TypeRegistry) unionVariants(schema *openapi3.Schema, refs openapi3.SchemaRefs, deps map[string]struct{}) ([]unionVariant, bool) {
+	if schema.Discriminator == nil || strings.TrimSpace(schema.Discriminator.PropertyName) == "" || len(refs) == 0 {
+		return nil, false
+	}
+	property := strings.TrimSpace(schema.Discriminator.PropertyName)
+
+	// mapping is value -> target; targets are refs or bare component names.
+	mapped := map[string][]string{}
+	values := make([]string, 0, len(schema.Discriminator.Mapping))
+	for value := range schema.Discriminator.Mapping {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	for _, value := range values {
+		target := refComponentName(schema.Discriminator.Mapping[value])
+		mapped[target] = append(mapped[target], enumLiteral(value))
+	}
+
+	variants := make([]unionVariant, 0, len(refs))
+	for _, ref := range refs {
+		if ref == nil {
+			continue
+		}
+		variant := unionVariant{Type: r.SchemaToType(ref, deps)}
+		declared, required := declaredDiscriminator(ref.Value, property, map[*openapi3.Schema]struct{}{})
+		switch {
+		case len(declared) > 0:
+			variant.Literals = declared
+		case ref.Ref != "":
+			variant.Literals = mapped[refComponentName(ref.Ref)]
+			if len(variant.Literals) == 0 {
+				variant.Literals = []string{enumLiteral(refComponentName(ref.Ref))}
+			}
+		}
+		if ref.Ref != "" {
+			variant.Name = variant.Type
+		} else if len(variant.Literals) > 0 {
+			variant.Name = sanitizeTypeName(strings.Trim(variant.Literals[0], "'"))
+		}
+		if !required && len(variant.Literals) > 0 {
+			base := variant.Type
+			if strings.Contains(base, " | ") {
+				base = "(" + base + ")"
+			}
+			variant.Type = base + " & { " + propertyKey(property) + ": " + strings.Join(variant.Literals, " | ") + " }"
+		}
+		variants = append(variants, variant)
+	}
+	return variants, len(variants) > 0
+}
+
+// declaredDiscriminator returns the literals a variant already declares for the discriminator
+// property, directly or through allOf, and whether it is also required so TypeScript can already
+// narrow on it. The literals are empty when the property is missing or not a literal.
+func declaredDiscriminator(schema *openapi3.Schema, property string, seen map[*openapi3.Schema]struct{}) (literals []string, required bool) {
+	if schema == nil {
+		return nil, false
+	}
+	if _, ok := seen[schema]; ok {
+		return nil, false
+	}
+	seen[schema] = struct{}{}
+	if prop := schema.Properties[property]; prop != nil && prop.Value != nil {
+		if literal, ok := constType(prop.Value); ok {
+			literals = []string{literal}
+		} else {
+			for _, value := range prop.Value.Enum {
+				if _, ok := value.(string); ok {
+					literals = append(literals, enumLiteral(value))
+				}
+			}
+			if len(literals) != len(prop.Value.Enum) {
+				literals = nil
+			}
+		}
+	}
+	for _, name := range schema.Required {
+		if name == property {
+			required = true
+		}
+	}
+	for _, part := range schema.AllOf {
+		if part == nil {
+			continue
+		}
+		partLiterals, partRequired := declaredDiscriminator(part.Value, property, seen)
+		if len(literals) == 0 {
+			literals = partLiterals
+		}
+		required = required || partRequired
+	}
+	return literals, required && len(literals) > 0
+}
+
+// refComponentName returns the component name of a ref such as #/components/schemas/Cat, or the
+// value itself for bare names allowed in discriminator mappings.
+func refComponentName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+func propertyKey(name string) string {
+	if isValidIdentifier(name) {
+		return name
+	}
+	return "'" + escapeTSString(name) + "'"
+}
+
+func propertyAccess(name string) string {
+	if isValidIdentifier(name) {
+		return "." + name
+	}
+	return "['" + escapeTSString(name) + "']"
+}
+
+// discriminatedSchema returns the discriminated oneOf/anyOf behind def when type guards are on.
+// Nullable unions are skipped because the guards read the property without a null check.
+func (r *TypeRegistry) discriminatedSchema(def *TypeDef) (*openapi3.Schema, openapi3.SchemaRefs) {
+	if !r.typeGuards || def == nil || def.Schema == nil || def.omitFrom != "" {
+		return nil, nil
+	}
+	schemaRef := def.Schema
+	if schemaRef.Ref != "" {
+		schemaRef = r.resolveRefSchema(schemaRef.Ref)
+	}
+	if schemaRef == nil || schemaRef.Value == nil || schemaRef.Value.Nullable || schemaRef.Value.Discriminator == nil {
+		return nil, nil
+	}
+	if _, overridden := r.lookupOverride(schemaRef); overridden {
+		return nil, nil
+	}
+	schema := schemaRef.Value
+	if len(schema.OneOf) > 0 {
+		return schema, schema.OneOf
+	}
+	return schema, schema.AnyOf
+}
+
+// typeGuard is one isXxx function of a discriminated union.
+type typeGuard struct {
+	Name     string
+	Literals []string
+}
+
+// unionTypeGuards lists the guards emitted for def in name order, one per variant with a known
+// discriminator value and a distinct name.
+func (r *TypeRegistry) unionTypeGuards(def *TypeDef) []typeGuard {
+	schema, refs := r.discriminatedSchema(def)
+	if schema == nil {
+		return nil
+	}
+	variants, ok := r.unionVariants(schema, refs, nil)
+	if !ok {
+		return nil
+	}
+	var guards []typeGuard
+	seen := map[string]struct{}{}
+	for _, variant := range variants {
+		if len(variant.Literals) == 0 || variant.Name == "" {
+			continue
+		}
+		name := "is" + def.Name + upperFirst(variant.Name)
+		if _, dup := seen[name]; dup || !isValidIdentifier(name) {
+			continue
+		}
+		seen[name] = struct{}{}
+		guards = append(guards, typeGuard{Name: name, Literals: variant.Literals})
+	}
+	sort.Slice(guards, func(i, j int) bool { return guards[i].Name < guards[j].Name })
+	return guards
+}
+
+// typeGuardNames returns the functions renderTypeGuards emits for def.
+func (r *TypeRegistry) typeGuardNames(def *TypeDef) []string {
+	var names []string
+	for _, guard := range r.unionTypeGuards(def) {
+		names = append(names, guard.Name)
+	}
+	return names
+}
+
+// renderTypeGuards emits one `value is` function per variant of a discriminated union:
+//
+//	export function isPetCat(value: Pet): value is Extract<Pet, { petType: 'cat' }> {
+//	  return value.petType === 'cat';
+//	}
+func (r *TypeRegistry) renderTypeGuards(def *TypeDef) string {
+	guards := r.unionTypeGuards(def)
+	if len(guards) == 0 {
+		return ""
+	}
+	schema, _ := r.discriminatedSchema(def)
+	property := strings.TrimSpace(schema.Discriminator.PropertyName)
+	var b strings.Builder
+	for _, guard := range guards {
+		checks := make([]string, 0, len(guard.Literals))
+		for _, literal := range guard.Literals {
+			checks = append(checks, "value"+propertyAccess(property)+" === "+literal)
+		}
+		b.WriteString("export function " + guard.Name + "(value: " + def.Name + "): value is Extract<" + def.Name + ", { " + propertyKey(property) + ": " + strings.Join(guard.Literals, " | ") + " }> {\n")
+		b.WriteString("  return " + strings.Join(checks, " || ") + ";\n")
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func buildPetDoc() *openapi3.T {
+	cat := &openapi3.Schema{
+		Type:       typesOf("object"),
+		Required:   []string{"petType"},
+		Properties: openapi3.Schemas{"petType": {Value: &openapi3.Schema{Type: typesOf("string")}}},
+	}
+	dog := &openapi3.Schema{
+		Type:       typesOf("object"),
+		Required:   []string{"petType"},
+		Properties: openapi3.Schemas{"petType": {Value: &openapi3.Schema{Type: typesOf("string"), Enum: []any{"dog"}}}},
+	}
+	bird := &openapi3.Schema{
+		Type:       typesOf("object"),
+		Properties: openapi3.Schemas{"petType": {Value: &openapi3.Schema{Type: typesOf("string"), Enum: []any{"bird"}}}},
+	}
+	components := openapi3.NewComponents()
+	components.Schemas = openapi3.Schemas{
+		"Cat":  {Value: cat},
+		"Dog":  {Value: dog},
+		"Bird": {Value: bird},
+		"Pet": {Value: &openapi3.Schema{
+			OneOf: openapi3.SchemaRefs{
+				{Ref: "#/components/schemas/Cat", Value: cat},
+				{Ref: "#/components/schemas/Dog", Value: dog},
+				{Ref: "#/components/schemas/Bird", Value: bird},
+			},
+			Discriminator: &openapi3.Discriminator{
+				PropertyName: "petType",
+				Mapping:      openapi3.StringMap{"cat": "#/components/schemas/Cat", "kitten": "Cat"},
+			},
+		}},
+	}
+	return &openapi3.T{Components: &components, Paths: openapi3.NewPaths()}
+}
+
+func renderPet(t *testing.T, typeGuards bool) (string, *TypeRegistry) {
+	t.Helper()
+	registry := NewTypeRegistry(buildPetDoc())
+	registry.typeGuards = typeGuards
+	if _, err := registry.RegisterRef("#/components/schemas/Pet"); err != nil {
+		t.Fatalf("RegisterRef returned error: %v", err)
+	}
+	content, _ := RenderType(registry.Types()[0], registry)
+	return content, registry
+}
+
+func TestRenderType_DiscriminatedUnionInjectsLiterals(t *testing.T) {
+	content, _ := renderPet(t, false)
+	// Cat takes its values from the mapping, Dog already narrows, Bird's literal is optional.
+	want := "export type Pet = Cat & { petType: 'cat' | 'kitten' } | Dog | Bird & { petType: 'bird' };\n"
+	if content != want {
+		t.Fatalf("unexpected union:\n%s", content)
+	}
+}
+
+func TestRenderType_DiscriminatedUnionTypeGuards(t *testing.T) {
+	content, registry := renderPet(t, true)
+	for _, want := range []string{
+		"export function isPetCat(value: Pet): value is Extract<Pet, { petType: 'cat' | 'kitten' }> {\n  return value.petType === 'cat' || value.petType === 'kitten';\n}\n",
+		"export function isPetDog(value: Pet): value is Extract<Pet, { petType: 'dog' }> {\n  return value.petType === 'dog';\n}\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("missing guard %q:\n%s", want, content)
+		}
+	}
+	var names []string
+	for _, def := range registry.Types() {
+		names = append(names, registry.typeGuardNames(def)...)
+	}
+	if strings.Join(names, ",") != "isPetBird,isPetCat,isPetDog" {
+		t.Fatalf("unexpected guard names: %v", names)
+	}
+}
+
+func TestRenderType_UnionWithoutDiscriminatorIsUnchanged(t *testing.T) {
+	doc := buildPetDoc()
+	doc.Components.Schemas["Pet"].Value.Discriminator = nil
+	registry := NewTypeRegistry(doc)
+	registry.typeGuards = true
+	if _, err := registry.RegisterRef("#/components/schemas/Pet"); err != nil {
+		t.Fatalf("RegisterRef returned error: %v", err)
+	}
+	content, _ := RenderType(registry.Types()[0], registry)
+	if content != "export type Pet = Cat | Dog | Bird;\n" {
+		t.Fatalf("unexpected union:\n%s", content)
+	}
+}
@@ -0,0 +1,184 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Formats lists the output formats accepted by Write.
+var Formats = []string{"text", "json", "sarif"}
+
+// Summary counts findings per severity.
+type Summary struct {
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+	Infos    int `json:"infos"`
+}
+
+// Summarize counts the findings per severity.
+func Summarize(findings []Finding) Summary {
+	var summary Summary
+	for _, finding := range findings {
+		switch finding.Severity {
+		case SeverityError:
+			summary.Errors++
+		case SeverityWarning:
+			summary.Warnings++
+		case SeverityInfo:
+			summary.Infos++
+		}
+	}
+	return summary
+}
+
+// Write prints the findings in the given format: text, json or sarif.
+func Write(w io.Writer, format string, findings []Finding) error {
+	switch format {
+	case "text", "":
+		return writeText(w, findings)
+	case "json":
+		return writeJSON(w, struct {
+			Findings []Finding `json:"findings"`
+			Summary  Summary   `json:"summary"`
+		}{Findings: nonNil(findings), Summary: Summarize(findings)})
+	case "sarif":
+		return writeJSON(w, sarifLog(findings))
+	}
+	return fmt.Errorf("unknown lint format %q (expected %s)", format, strings.Join(Formats, ", "))
+}
+
+func writeText(w io.Writer, findings []Finding) error {
+	for _, finding := range findings {
+		location := finding.Pointer
+		if finding.Method != "" {
+			location = finding.Method + " " + finding.Path
+		}
+		if location == "" {
+			location = finding.Source
+		}
+		if _, err := fmt.Fprintf(w, "%-7s %-23s %s: %s\n", finding.Severity, finding.Rule, location, finding.Message); err != nil {
+			return err
+		}
+	}
+	summary := Summarize(findings)
+	_, err := fmt.Fprintf(w, "%d errors, %d warnings, %d infos\n", summary.Errors, summary.Warnings, summary.Infos)
+	return err
+}
+
+func writeJSON(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func nonNil(findings []Finding) []Finding {
+	if findings == nil {
+		return []Finding{}
+	}
+	return findings
+}
+
+// SARIF 2.1.0, limited to the properties code scanning tools read.
+type sarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	Properties       map[string]string     `json:"properties,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+func sarifLog(findings []Finding) sarifReport {
+	rules := make([]sarifRule, 0, len(Rules))
+	for _, rule := range Rules {
+		rules = append(rules, sarifRule{
+			ID:                   rule.ID,
+			ShortDescription:     sarifMessage{Text: rule.Description},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.Severity)},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(findings))
+	for _, finding := range findings {
+		result := sarifResult{RuleID: finding.Rule, Level: sarifLevel(finding.Severity), Message: sarifMessage{Text: finding.Message}}
+		if finding.Source != "" {
+			location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: finding.Source}}}
+			if finding.Pointer != "" {
+				location.Properties = map[string]string{"pointer": finding.Pointer}
+			}
+			result.Locations = []sarifLocation{location}
+		}
+		results = append(results, result)
+	}
+
+	return sarifReport{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "swagger-ts",
+				InformationURI: "https://github.com/gopkg-dev/swagger-ts-gen",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+}
+
+func sarifLevel(severity Severity) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	}
+	return "note"
+}
@@ -0,0 +1,244 @@
+// Package lint checks specs for problems that kin-openapi validation misses but that degrade the
+// generated client: anonymous operations, suffixed function names, void returns and broken refs.
+package lint
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/gopkg-dev/swagger-ts-gen/internal/generator"
+	"github.com/gopkg-dev/swagger-ts-gen/internal/loader"
+)
+
+// Severity ranks findings; SeverityOff disables a rule.
+type Severity string
+
+const (
+	SeverityOff     Severity = "off"
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// ParseSeverity accepts off, info, warning and error.
+func ParseSeverity(value string) (Severity, error) {
+	switch severity := Severity(strings.ToLower(strings.TrimSpace(value))); severity {
+	case SeverityOff, SeverityInfo, SeverityWarning, SeverityError:
+		return severity, nil
+	}
+	return "", fmt.Errorf("unknown severity %q (expected off, info, warning or error)", value)
+}
+
+func (s Severity) rank() int {
+	switch s {
+	case SeverityInfo:
+		return 1
+	case SeverityWarning:
+		return 2
+	case SeverityError:
+		return 3
+	}
+	return 0
+}
+
+// AtLeast reports whether s is enabled and not below threshold.
+func (s Severity) AtLeast(threshold Severity) bool {
+	return s.rank() > 0 && s.rank() >= threshold.rank()
+}
+
+const (
+	RuleSpecValidation        = "spec-validation"
+	RuleMissingOperationID    = "missing-operation-id"
+	RuleDuplicateFunctionName = "duplicate-function-name"
+	RuleUnresolvedRef         = "unresolved-ref"
+	RuleEmptyDataSchema       = "empty-data-schema"
+	RuleMissingPathParam      = "missing-path-param"
+)
+
+// Rule describes one check and its default severity.
+type Rule struct {
+	ID          string
+	Description string
+	Severity    Severity
+}
+
+// Rules lists every check in reporting order.
+var Rules = []Rule{
+	{RuleSpecValidation, "The document must pass OpenAPI validation.", SeverityError},
+	{RuleMissingOperationID, "Operations should declare an operationId; otherwise the function name is derived from the method and path.", SeverityWarning},
+	{RuleDuplicateFunctionName, "Operations in one group must not map to the same function name; the generator would suffix it with 2, 3, ...", SeverityWarning},
+	{RuleUnresolvedRef, "Schema $refs must resolve to a component; unresolved refs are generated as any.", SeverityError},
+	{RuleEmptyDataSchema, "Success responses of GET operations should describe the envelope data; otherwise the function resolves to void.", SeverityWarning},
+	{RuleMissingPathParam, "Every {param} in a path template must be declared as a path parameter.", SeverityError},
+}
+
+// Finding is one reported problem. Pointer is a JSON pointer into the (converted) OpenAPI 3 document.
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	Source   string   `json:"source,omitempty"`
+	Method   string   `json:"method,omitempty"`
+	Path     string   `json:"path,omitempty"`
+	Pointer  string   `json:"pointer,omitempty"`
+}
+
+// Options configures a lint run. Generator carries the envelope and grouping settings so
+// generator-aware rules see the same groups and return types as a generation run.
+type Options struct {
+	Generator generator.Options
+	// Severities overrides the default severity per rule ID.
+	Severities map[string]Severity
+	// UnresolvedRefs lists, per source name, the local $refs the loader dropped with
+	// AllowUnresolvedRefs. Each is reported as unresolved-ref.
+	UnresolvedRefs map[string][]loader.UnresolvedRef
+}
+
+// Lint runs every enabled rule over the sources. Findings are sorted by source, pointer and rule.
+func Lint(sources []generator.Source, opts Options) ([]Finding, error) {
+	for id, severity := range opts.Severities {
+		if ruleByID(id) == nil {
+			return nil, fmt.Errorf("unknown lint rule %q", id)
+		}
+		if _, err := ParseSeverity(string(severity)); err != nil {
+			return nil, fmt.Errorf("lint rule %s: %w", id, err)
+		}
+	}
+
+	l := &linter{opts: opts}
+	for _, source := range sources {
+		l.source = source.Name
+		for _, ref := range opts.UnresolvedRefs[source.Name] {
+			l.report(Finding{Rule: RuleUnresolvedRef, Pointer: ref.Pointer, Message: fmt.Sprintf("$ref %q does not resolve", ref.Ref)})
+		}
+		l.checkSpec(source.Spec)
+	}
+
+	planned, err := generator.NewFromSources(sources, opts.Generator).Plan()
+	if err != nil {
+		return nil, err
+	}
+	l.checkPlan(sources, planned)
+
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		a, b := l.findings[i], l.findings[j]
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		if a.Pointer != b.Pointer {
+			return a.Pointer < b.Pointer
+		}
+		return a.Rule < b.Rule
+	})
+	return l.findings, nil
+}
+
+type linter struct {
+	opts     Options
+	source   string
+	findings []Finding
+}
+
+func (l *linter) report(finding Finding) {
+	severity := ruleByID(finding.Rule).Severity
+	if override, ok := l.opts.Severities[finding.Rule]; ok {
+		severity = override
+	}
+	if severity == SeverityOff {
+		return
+	}
+	finding.Severity = severity
+	if finding.Source == "" {
+		finding.Source = l.source
+	}
+	l.findings = append(l.findings, finding)
+}
+
+func (l *linter) checkSpec(doc *openapi3.T) {
+	if err := doc.Validate(context.Background()); err != nil {
+		l.report(Finding{Rule: RuleSpecValidation, Message: err.Error()})
+	}
+
+	for _, path := range doc.Paths.InMatchingOrder() {
+		item := doc.Paths.Value(path)
+		for method, op := range item.Operations() {
+			pointer := "/paths/" + escapePointer(path) + "/" + strings.ToLower(method)
+			if strings.TrimSpace(op.OperationID) == "" {
+				l.report(Finding{Rule: RuleMissingOperationID, Method: method, Path: path, Pointer: pointer,
+					Message: "operation has no operationId"})
+			}
+			l.checkPathParams(method, path, pointer, item.Parameters, op.Parameters)
+		}
+	}
+
+	walker := &refWalker{doc: doc, visited: map[*openapi3.Schema]bool{}, report: func(pointer string, ref string) {
+		l.report(Finding{Rule: RuleUnresolvedRef, Pointer: pointer, Message: fmt.Sprintf("$ref %q does not resolve to a schema", ref)})
+	}}
+	walker.walkDocument()
+}
+
+var pathTemplateParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+func (l *linter) checkPathParams(method string, path string, pointer string, params ...openapi3.Parameters) {
+	declared := map[string]bool{}
+	for _, list := range params {
+		for _, param := range list {
+			if param != nil && param.Value != nil && param.Value.In == openapi3.ParameterInPath {
+				declared[param.Value.Name] = true
+			}
+		}
+	}
+	for _, match := range pathTemplateParam.FindAllStringSubmatch(path, -1) {
+		if !declared[match[1]] {
+			l.report(Finding{Rule: RuleMissingPathParam, Method: method, Path: path, Pointer: pointer,
+				Message: fmt.Sprintf("path parameter %q is not declared", match[1])})
+		}
+	}
+}
+
+func (l *linter) checkPlan(sources []generator.Source, planned []generator.PlannedOperation) {
+	byFunction := map[string][]generator.PlannedOperation{}
+	for _, op := range planned {
+		key := op.Group + "/" + op.BaseName
+		byFunction[key] = append(byFunction[key], op)
+	}
+
+	for _, op := range planned {
+		method := strings.ToUpper(op.Method)
+		pointer := "/paths/" + escapePointer(op.Path) + "/" + strings.ToLower(op.Method)
+		source := sources[op.Source].Name
+		if op.Function != op.BaseName {
+			first := byFunction[op.Group+"/"+op.BaseName][0]
+			l.report(Finding{Rule: RuleDuplicateFunctionName, Source: source, Method: method, Path: op.Path, Pointer: pointer,
+				Message: fmt.Sprintf("function name %s in group %s is already used by %s %s; generated as %s",
+					op.BaseName, op.Group, strings.ToUpper(first.Method), first.Path, op.Function)})
+		}
+		if op.EmptyData && method == "GET" {
+			l.report(Finding{Rule: RuleEmptyDataSchema, Source: source, Method: method, Path: op.Path, Pointer: pointer,
+				Message: fmt.Sprintf("success response has no data schema; %s resolves to void", op.Function)})
+		}
+	}
+}
+
+// IsRule reports whether id names a lint rule.
+func IsRule(id string) bool {
+	return ruleByID(id) != nil
+}
+
+func ruleByID(id string) *Rule {
+	for idx := range Rules {
+		if Rules[idx].ID == id {
+			return &Rules[idx]
+		}
+	}
+	return nil
+}
+
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
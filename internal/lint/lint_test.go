@@ -0,0 +1,126 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/gopkg-dev/swagger-ts-gen/internal/generator"
+)
+
+func TestLint_ReportsGeneratorAwareRules(t *testing.T) {
+	findings, err := Lint([]generator.Source{{Spec: buildLintDoc(), Name: "spec.json"}}, Options{})
+	if err != nil {
+		t.Fatalf("Lint returned error: %v", err)
+	}
+
+	got := map[string][]Finding{}
+	for _, finding := range findings {
+		got[finding.Rule] = append(got[finding.Rule], finding)
+	}
+	for _, rule := range []string{RuleMissingOperationID, RuleDuplicateFunctionName, RuleUnresolvedRef, RuleEmptyDataSchema, RuleMissingPathParam} {
+		if len(got[rule]) != 1 {
+			t.Fatalf("expected one %s finding, got %+v", rule, findings)
+		}
+	}
+
+	duplicate := got[RuleDuplicateFunctionName][0]
+	if duplicate.Method != "POST" || duplicate.Path != "/api/v1/users" || duplicate.Severity != SeverityWarning {
+		t.Fatalf("unexpected duplicate finding: %+v", duplicate)
+	}
+	if ref := got[RuleUnresolvedRef][0]; ref.Pointer != "/components/schemas/Team/properties/owner" || ref.Source != "spec.json" {
+		t.Fatalf("unexpected unresolved ref finding: %+v", ref)
+	}
+	if param := got[RuleMissingPathParam][0]; param.Pointer != "/paths/~1api~1v1~1users~1{id}/get" {
+		t.Fatalf("unexpected path param finding: %+v", param)
+	}
+}
+
+func TestLint_SeverityOverrides(t *testing.T) {
+	findings, err := Lint([]generator.Source{{Spec: buildLintDoc()}}, Options{Severities: map[string]Severity{
+		RuleSpecValidation:     SeverityOff,
+		RuleMissingOperationID: SeverityOff,
+		RuleEmptyDataSchema:    SeverityError,
+	}})
+	if err != nil {
+		t.Fatalf("Lint returned error: %v", err)
+	}
+	for _, finding := range findings {
+		switch finding.Rule {
+		case RuleSpecValidation, RuleMissingOperationID:
+			t.Fatalf("disabled rule reported: %+v", finding)
+		case RuleEmptyDataSchema:
+			if finding.Severity != SeverityError {
+				t.Fatalf("override not applied: %+v", finding)
+			}
+		}
+	}
+
+	if _, err := Lint(nil, Options{Severities: map[string]Severity{"no-such-rule": SeverityError}}); err == nil {
+		t.Fatal("expected unknown rule error")
+	}
+}
+
+func TestWrite_SARIF(t *testing.T) {
+	findings := []Finding{{Rule: RuleMissingOperationID, Severity: SeverityInfo, Message: "operation has no operationId", Source: "spec.json", Pointer: "/paths/~1a/get"}}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, "sarif", findings); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	var report sarifReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid SARIF JSON: %v", err)
+	}
+	if report.Version != "2.1.0" || len(report.Runs) != 1 || len(report.Runs[0].Tool.Driver.Rules) != len(Rules) {
+		t.Fatalf("unexpected SARIF log: %s", buf.String())
+	}
+	result := report.Runs[0].Results[0]
+	if result.Level != "note" || result.Locations[0].PhysicalLocation.ArtifactLocation.URI != "spec.json" || result.Locations[0].Properties["pointer"] != "/paths/~1a/get" {
+		t.Fatalf("unexpected SARIF result: %+v", result)
+	}
+
+	if err := Write(&buf, "xml", findings); err == nil {
+		t.Fatal("expected unknown format error")
+	}
+}
+
+func buildLintDoc() *openapi3.T {
+	components := openapi3.NewComponents()
+	components.Schemas = openapi3.Schemas{
+		"User": {Value: &openapi3.Schema{Type: &openapi3.Types{"object"}, Properties: openapi3.Schemas{
+			"id": {Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+		}}},
+		"Team": {Value: &openapi3.Schema{Type: &openapi3.Types{"object"}, Properties: openapi3.Schemas{
+			"owner": {Ref: "#/components/schemas/Owner"},
+		}}},
+	}
+
+	envelope := func(data *openapi3.SchemaRef) *openapi3.Responses {
+		properties := openapi3.Schemas{"code": {Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}}}}
+		if data != nil {
+			properties["data"] = data
+		}
+		schema := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"object"}, Properties: properties}}
+		response := openapi3.NewResponse().WithDescription("ok").WithContent(openapi3.NewContentWithJSONSchemaRef(schema))
+		return openapi3.NewResponses(openapi3.WithStatus(200, &openapi3.ResponseRef{Value: response}))
+	}
+	userRef := &openapi3.SchemaRef{Ref: "#/components/schemas/User", Value: components.Schemas["User"].Value}
+
+	doc := &openapi3.T{
+		OpenAPI:    "3.0.3",
+		Info:       &openapi3.Info{Title: "lint", Version: "1"},
+		Components: &components,
+		Paths:      openapi3.NewPaths(),
+	}
+	doc.Paths.Set("/api/v1/users", &openapi3.PathItem{
+		Get:  &openapi3.Operation{OperationID: "listUsers", Responses: envelope(nil)},
+		Post: &openapi3.Operation{OperationID: "listUsers", Responses: envelope(userRef)},
+	})
+	doc.Paths.Set("/api/v1/users/{id}", &openapi3.PathItem{
+		Get: &openapi3.Operation{Responses: envelope(userRef)},
+	})
+	return doc
+}
@@ -0,0 +1,132 @@
+package lint
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// refWalker visits every schema reachable from the document and reports $refs without a value
+// or pointing at a missing component.
+type refWalker struct {
+	doc     *openapi3.T
+	visited map[*openapi3.Schema]bool
+	report  func(pointer string, ref string)
+}
+
+func (w *refWalker) walkDocument() {
+	if components := w.doc.Components; components != nil {
+		for _, name := range sortedKeys(components.Schemas) {
+			w.walkSchema(components.Schemas[name], "/components/schemas/"+escapePointer(name))
+		}
+		for _, name := range sortedKeys(components.Parameters) {
+			w.walkParameter(components.Parameters[name], "/components/parameters/"+escapePointer(name))
+		}
+		for _, name := range sortedKeys(components.RequestBodies) {
+			if body := components.RequestBodies[name]; body != nil && body.Value != nil {
+				w.walkContent(body.Value.Content, "/components/requestBodies/"+escapePointer(name)+"/content")
+			}
+		}
+		for _, name := range sortedKeys(components.Responses) {
+			if response := components.Responses[name]; response != nil && response.Value != nil {
+				w.walkContent(response.Value.Content, "/components/responses/"+escapePointer(name)+"/content")
+			}
+		}
+	}
+
+	for _, path := range w.doc.Paths.InMatchingOrder() {
+		item := w.doc.Paths.Value(path)
+		itemPointer := "/paths/" + escapePointer(path)
+		for idx, param := range item.Parameters {
+			w.walkParameter(param, itemPointer+"/parameters/"+strconv.Itoa(idx))
+		}
+		for method, op := range item.Operations() {
+			opPointer := itemPointer + "/" + strings.ToLower(method)
+			for idx, param := range op.Parameters {
+				w.walkParameter(param, opPointer+"/parameters/"+strconv.Itoa(idx))
+			}
+			if op.RequestBody != nil && op.RequestBody.Value != nil {
+				w.walkContent(op.RequestBody.Value.Content, opPointer+"/requestBody/content")
+			}
+			if op.Responses == nil {
+				continue
+			}
+			for status, response := range op.Responses.Map() {
+				if response != nil && response.Value != nil {
+					w.walkContent(response.Value.Content, opPointer+"/responses/"+escapePointer(status)+"/content")
+				}
+			}
+		}
+	}
+}
+
+func (w *refWalker) walkParameter(param *openapi3.ParameterRef, pointer string) {
+	if param == nil || param.Value == nil {
+		return
+	}
+	w.walkSchema(param.Value.Schema, pointer+"/schema")
+	w.walkContent(param.Value.Content, pointer+"/content")
+}
+
+func (w *refWalker) walkContent(content openapi3.Content, pointer string) {
+	for _, mediaType := range sortedKeys(content) {
+		if media := content[mediaType]; media != nil {
+			w.walkSchema(media.Schema, pointer+"/"+escapePointer(mediaType)+"/schema")
+		}
+	}
+}
+
+func (w *refWalker) walkSchema(schemaRef *openapi3.SchemaRef, pointer string) {
+	if schemaRef == nil {
+		return
+	}
+	if schemaRef.Ref != "" && !w.resolves(schemaRef) {
+		w.report(pointer, schemaRef.Ref)
+		return
+	}
+	schema := schemaRef.Value
+	if schema == nil || w.visited[schema] {
+		return
+	}
+	w.visited[schema] = true
+
+	for _, name := range sortedKeys(schema.Properties) {
+		w.walkSchema(schema.Properties[name], pointer+"/properties/"+escapePointer(name))
+	}
+	w.walkSchema(schema.Items, pointer+"/items")
+	w.walkSchema(schema.AdditionalProperties.Schema, pointer+"/additionalProperties")
+	w.walkSchema(schema.Not, pointer+"/not")
+	for keyword, refs := range map[string]openapi3.SchemaRefs{"allOf": schema.AllOf, "oneOf": schema.OneOf, "anyOf": schema.AnyOf} {
+		for idx, ref := range refs {
+			w.walkSchema(ref, pointer+"/"+keyword+"/"+strconv.Itoa(idx))
+		}
+	}
+}
+
+// resolves reports whether a schema $ref has a value and, for local component refs, a component.
+// External refs are internalized by the loader, so anything else left over is unresolved.
+func (w *refWalker) resolves(schemaRef *openapi3.SchemaRef) bool {
+	if schemaRef.Value == nil {
+		return false
+	}
+	const prefix = "#/components/schemas/"
+	if !strings.HasPrefix(schemaRef.Ref, prefix) {
+		return false
+	}
+	if w.doc.Components == nil {
+		return false
+	}
+	_, ok := w.doc.Components.Schemas[strings.TrimPrefix(schemaRef.Ref, prefix)]
+	return ok
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
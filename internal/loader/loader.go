@@ -1,14 +1,15 @@
 package loader
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/getkin/kin-openapi/openapi2"
 	"github.com/getkin/kin-openapi/openapi2conv"
@@ -16,17 +17,70 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
+// StdinInput is the input value that reads the spec from standard input.
+const StdinInput = "-"
+
+// stdinSource is reported as Meta.Source for specs read from standard input.
+const stdinSource = "<stdin>"
+
+// stdin is swapped in tests.
+var stdin io.Reader = os.Stdin
+
 type Meta struct {
 	Source  string
 	Version string
+	// Warnings lists parts of the spec that are ignored, e.g. OpenAPI 3.1 webhooks.
+	Warnings []string
+	// Files lists the local files read for external $refs, sorted, so watchers can track them.
+	Files []string
+	// UnresolvedRefs lists the local $refs dropped because Options.AllowUnresolvedRefs is set.
+	UnresolvedRefs []UnresolvedRef
+}
+
+func Load(input string, opts Options) (*openapi3.T, *Meta, error) {
+	return LoadContext(context.Background(), input, opts)
+}
+
+// LoadContext is Load with a context that cancels remote fetches of the spec and its external refs.
+func LoadContext(ctx context.Context, input string, opts Options) (*openapi3.T, *Meta, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	data, source, location, err := readInput(ctx, input, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return load(ctx, data, source, location, opts)
 }
 
-func Load(input string) (*openapi3.T, *Meta, error) {
-	data, source, err := readInput(input)
+// LoadData is LoadContext for a spec whose bytes were already read from input, e.g. the body
+// Poll fetched, so a URL is not downloaded twice. input still names the source and is the base
+// URI of relative external $refs.
+func LoadData(ctx context.Context, input string, data []byte, opts Options) (*openapi3.T, *Meta, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	trimmed := strings.TrimSpace(input)
+	if trimmed == StdinInput {
+		return nil, nil, errors.New("stdin input cannot be loaded from data")
+	}
+	if isURL(trimmed) {
+		location, err := url.Parse(trimmed)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid url %s", redactURL(trimmed))
+		}
+		return load(ctx, data, redactURL(trimmed), location, opts)
+	}
+	location, err := fileLocation(trimmed)
 	if err != nil {
 		return nil, nil, err
 	}
+	return load(ctx, data, trimmed, location, opts)
+}
 
+// load parses the spec bytes read from location, resolves external $refs against it and
+// converts Swagger 2.0 to OpenAPI 3.
+func load(ctx context.Context, data []byte, source string, location *url.URL, opts Options) (*openapi3.T, *Meta, error) {
 	jsonData, err := toJSON(data)
 	if err != nil {
 		return nil, nil, err
@@ -37,56 +91,127 @@ func Load(input string) (*openapi3.T, *Meta, error) {
 		return nil, nil, err
 	}
 
-	if version == "openapi3" {
-		loader := openapi3.NewLoader()
-		loader.IsExternalRefsAllowed = true
-		doc, err := loader.LoadFromData(jsonData)
+	// The location is the base URI for relative external $refs such as ./schemas/user.yaml.
+	refs := &refReader{ctx: ctx, opts: opts, root: location}
+	loader := refs.newLoader()
+	if version == "openapi3" || version == "openapi31" {
+		meta := &Meta{Source: source, Version: "OpenAPI 3"}
+		if version == "openapi31" {
+			meta.Version = "OpenAPI 3.1"
+			jsonData, meta.Warnings, err = normalizeOpenAPI31(jsonData)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		if opts.AllowUnresolvedRefs {
+			jsonData, meta.UnresolvedRefs, err = dropUnresolvedRefs(jsonData)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		doc, err := loader.LoadFromDataWithPath(jsonData, location)
 		if err != nil {
-			return nil, nil, fmt.Errorf("load openapi3 failed: %w", err)
+			return nil, nil, fmt.Errorf("load openapi3 failed: %w", explainLoadError(jsonData, err))
 		}
-		return doc, &Meta{Source: source, Version: "OpenAPI 3"}, nil
+		internalizeRefs(doc)
+		meta.Files = refs.localFiles()
+		return doc, meta, nil
 	}
 
+	meta := &Meta{Source: source, Version: "Swagger 2.0"}
+	if opts.AllowUnresolvedRefs {
+		jsonData, meta.UnresolvedRefs, err = dropUnresolvedRefs(jsonData)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 	var doc2 openapi2.T
 	if err := json.Unmarshal(jsonData, &doc2); err != nil {
 		return nil, nil, fmt.Errorf("load swagger2 failed: %w", err)
 	}
-	doc3, err := openapi2conv.ToV3(&doc2)
+	doc3, err := openapi2conv.ToV3WithLoader(&doc2, loader, location)
 	if err != nil {
-		return nil, nil, fmt.Errorf("convert swagger2 to openapi3 failed: %w", err)
+		return nil, nil, fmt.Errorf("convert swagger2 to openapi3 failed: %w", explainLoadError(jsonData, err))
 	}
-
-	return doc3, &Meta{Source: source, Version: "Swagger 2.0"}, nil
+	internalizeRefs(doc3)
+	meta.Files = refs.localFiles()
+	return doc3, meta, nil
 }
 
-func readInput(input string) ([]byte, string, error) {
+// readInput returns the spec bytes, the source shown to users and the location used as base URI.
+// Stdin specs resolve relative refs against the working directory.
+func readInput(ctx context.Context, input string, opts Options) ([]byte, string, *url.URL, error) {
 	trimmed := strings.TrimSpace(input)
 	if trimmed == "" {
-		return nil, "", errors.New("input is empty")
+		return nil, "", nil, errors.New("input is empty")
 	}
 
-	if strings.HasPrefix(trimmed, "http://") || strings.HasPrefix(trimmed, "https://") {
-		client := &http.Client{Timeout: 20 * time.Second}
-		resp, err := client.Get(trimmed)
+	if trimmed == StdinInput {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("read stdin failed: %w", err)
+		}
+		if len(strings.TrimSpace(string(data))) == 0 {
+			return nil, "", nil, errors.New("read stdin failed: no spec was piped in")
+		}
+		location, err := fileLocation(stdinSource)
 		if err != nil {
-			return nil, "", fmt.Errorf("fetch url failed: %w", err)
+			return nil, "", nil, err
 		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, "", fmt.Errorf("fetch url failed: status %d", resp.StatusCode)
+		return data, stdinSource, location, nil
+	}
+
+	if isURL(trimmed) {
+		location, err := url.Parse(trimmed)
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("invalid url %s", redactURL(trimmed))
 		}
-		body, err := io.ReadAll(resp.Body)
+		body, source, err := fetchURL(ctx, trimmed, opts)
 		if err != nil {
-			return nil, "", fmt.Errorf("read url response failed: %w", err)
+			return nil, "", nil, err
 		}
-		return body, trimmed, nil
+		return body, source, location, nil
 	}
 
 	data, err := os.ReadFile(trimmed)
 	if err != nil {
-		return nil, "", fmt.Errorf("read file failed: %w", err)
+		return nil, "", nil, fmt.Errorf("read file failed: %w", err)
+	}
+	location, err := fileLocation(trimmed)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return data, trimmed, location, nil
+}
+
+// fetchURL downloads rawURL and returns the body with the redacted URL as source.
+func fetchURL(ctx context.Context, rawURL string, opts Options) ([]byte, string, error) {
+	source := redactURL(rawURL)
+	req, err := opts.newRequest(ctx, rawURL)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := opts.do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, "", fmt.Errorf("fetch %s failed: status %d", source, resp.StatusCode)
+	}
+	body, err := opts.readBody(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("read %s response failed: %w", source, err)
+	}
+	return body, source, nil
+}
+
+func fileLocation(path string) (*url.URL, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolve input path failed: %w", err)
 	}
-	return data, trimmed, nil
+	return &url.URL{Path: filepath.ToSlash(abs)}, nil
 }
 
 func toJSON(data []byte) ([]byte, error) {
@@ -109,7 +234,10 @@ func detectVersion(jsonData []byte) (string, error) {
 		return "", fmt.Errorf("parse json failed: %w", err)
 	}
 
-	if _, ok := probe["openapi"]; ok {
+	if version, ok := probe["openapi"]; ok {
+		if strings.HasPrefix(fmt.Sprint(version), "3.1") {
+			return "openapi31", nil
+		}
 		return "openapi3", nil
 	}
 	if _, ok := probe["swagger"]; ok {
@@ -0,0 +1,220 @@
+package loader
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad_ReadsStdin(t *testing.T) {
+	original := stdin
+	t.Cleanup(func() { stdin = original })
+
+	stdin = strings.NewReader("swagger: '2.0'\ninfo:\n  title: t\n  version: '1'\npaths: {}\n")
+	doc, meta, err := Load(" - ", Options{})
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if meta.Source != "<stdin>" || meta.Version != "Swagger 2.0" || doc.Info.Title != "t" {
+		t.Fatalf("unexpected result: source=%q version=%q title=%q", meta.Source, meta.Version, doc.Info.Title)
+	}
+
+	stdin = strings.NewReader("  \n")
+	if _, _, err := Load("-", Options{}); err == nil || !strings.Contains(err.Error(), "no spec was piped in") {
+		t.Fatalf("expected empty stdin error, got %v", err)
+	}
+}
+
+func TestLoad_InternalizesRelativeExternalRefs(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFile(t, dir, "openapi.yaml", `
+openapi: 3.0.0
+info: {title: t, version: '1'}
+paths:
+  /users/{id}:
+    get:
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: './schemas/user.yaml'}
+components:
+  schemas:
+    User:
+      $ref: './schemas/user.yaml'
+    Address:
+      type: object
+`)
+	writeSpecFile(t, dir, "schemas/user.yaml", `
+type: object
+properties:
+  address: {$ref: '../common.yaml#/components/schemas/Address'}
+`)
+	writeSpecFile(t, dir, "common.yaml", `
+components:
+  schemas:
+    Address:
+      type: object
+      properties:
+        city: {type: string}
+`)
+
+	doc, meta, err := Load(filepath.Join(dir, "openapi.yaml"), Options{})
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	wantFiles := []string{filepath.Join(dir, "common.yaml"), filepath.Join(dir, "schemas", "user.yaml")}
+	if strings.Join(meta.Files, ",") != strings.Join(wantFiles, ",") {
+		t.Fatalf("unexpected ref files: got %v want %v", meta.Files, wantFiles)
+	}
+	schema := doc.Paths.Find("/users/{id}").Get.Responses.Value("200").Value.Content["application/json"].Schema
+	if schema.Ref != "#/components/schemas/User" {
+		t.Fatalf("whole-file ref should reuse the root component, got %q", schema.Ref)
+	}
+	address := doc.Components.Schemas["User"].Value.Properties["address"]
+	if !strings.HasPrefix(address.Ref, "#/components/schemas/") || address.Ref == "#/components/schemas/Address" {
+		t.Fatalf("external Address must not collide with the root Address, got %q", address.Ref)
+	}
+	if address.Value == nil || address.Value.Properties["city"] == nil {
+		t.Fatalf("external Address should be resolved: %+v", address.Value)
+	}
+}
+
+func TestLoad_Swagger2ExternalRefsUseFragmentNames(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFile(t, dir, "swagger.yaml", `
+swagger: '2.0'
+info: {title: t, version: '1'}
+paths:
+  /orders:
+    get:
+      responses:
+        '200':
+          description: ok
+          schema: {$ref: './definitions.yaml#/Order'}
+`)
+	writeSpecFile(t, dir, "definitions.yaml", `
+Order:
+  type: object
+  properties:
+    item: {$ref: '#/Item'}
+Item:
+  type: object
+`)
+
+	doc, _, err := Load(filepath.Join(dir, "swagger.yaml"), Options{})
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	schema := doc.Paths.Find("/orders").Get.Responses.Value("200").Value.Content["application/json"].Schema
+	if schema.Ref != "#/components/schemas/Order" {
+		t.Fatalf("unexpected response ref %q", schema.Ref)
+	}
+	if item := doc.Components.Schemas["Order"].Value.Properties["item"]; item.Ref != "#/components/schemas/Item" {
+		t.Fatalf("unexpected nested ref %q", item.Ref)
+	}
+}
+
+func writeSpecFile(t *testing.T, dir string, name string, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("create dir failed: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s failed: %v", name, err)
+	}
+}
+
+func TestLoad_ExternalRefsGetCredentialsOnlyFromRootOrigin(t *testing.T) {
+	var otherAuth, otherTenant string
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		otherAuth, otherTenant = r.Header.Get("Authorization"), r.Header.Get("X-Tenant")
+		_, _ = w.Write([]byte(`{"type":"object","properties":{"city":{"type":"string"}}}`))
+	}))
+	defer other.Close()
+
+	var rootRefAuth string
+	root := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/schemas/user.json" {
+			rootRefAuth = r.Header.Get("Authorization")
+			_, _ = w.Write([]byte(`{"type":"object","properties":{"address":{"$ref":"` + other.URL + `/address.json"}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"openapi":"3.0.0","info":{"title":"t","version":"1"},"paths":{},
+			"components":{"schemas":{"User":{"$ref":"./schemas/user.json"}}}}`))
+	}))
+	defer root.Close()
+
+	opts := Options{Headers: map[string]string{"X-Tenant": "acme"}, BearerToken: "s3cret"}
+	if _, _, err := Load(root.URL+"/openapi.json", opts); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if rootRefAuth != "Bearer s3cret" {
+		t.Fatalf("refs on the spec origin should carry credentials, got %q", rootRefAuth)
+	}
+	if otherAuth != "" || otherTenant != "" {
+		t.Fatalf("refs on other origins must not carry credentials: Authorization=%q X-Tenant=%q", otherAuth, otherTenant)
+	}
+}
+
+func TestLoad_RemoteSpecRejectsLocalRefs(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFile(t, dir, "user.json", `{"type":"object"}`)
+	local := filepath.Join(dir, "user.json")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"openapi":"3.0.0","info":{"title":"t","version":"1"},"paths":{},
+			"components":{"schemas":{"User":{"$ref":"file://` + filepath.ToSlash(local) + `"}}}}`))
+	}))
+	defer server.Close()
+
+	_, _, err := Load(server.URL+"/openapi.json", Options{})
+	if err == nil || !strings.Contains(err.Error(), "a remote spec cannot refer to local files") {
+		t.Fatalf("expected local ref rejection, got %v", err)
+	}
+}
+
+func TestLoad_MissingLocalRefNamesRef(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFile(t, dir, "openapi.yaml", `
+openapi: 3.0.0
+info: {title: t, version: '1'}
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Resp'}
+`)
+
+	_, _, err := Load(filepath.Join(dir, "openapi.yaml"), Options{})
+	var unresolved *UnresolvedRefError
+	if !errors.As(err, &unresolved) || len(unresolved.Refs) != 1 {
+		t.Fatalf("expected an UnresolvedRefError, got %v", err)
+	}
+	want := `failed to resolve $ref "#/components/schemas/Resp" at /paths/~1users/get/responses/200/content/application~1json/schema`
+	if !strings.Contains(err.Error(), want) {
+		t.Fatalf("error should name the ref:\ngot  %v\nwant %s", err, want)
+	}
+
+	doc, meta, err := Load(filepath.Join(dir, "openapi.yaml"), Options{AllowUnresolvedRefs: true})
+	if err != nil {
+		t.Fatalf("lenient Load returned error: %v", err)
+	}
+	if len(meta.UnresolvedRefs) != 1 || meta.UnresolvedRefs[0] != unresolved.Refs[0] {
+		t.Fatalf("unexpected dropped refs: %+v", meta.UnresolvedRefs)
+	}
+	if schema := doc.Paths.Find("/users").Get.Responses.Value("200").Value.Content["application/json"].Schema; schema.Ref != "" {
+		t.Fatalf("dangling ref should be dropped, got %q", schema.Ref)
+	}
+}
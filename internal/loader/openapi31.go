@@ -0,0 +1,215 @@
+package loader
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// nameMapKeys hold user-chosen names rather than schema keywords, so keyword rewrites must
+// not touch their direct children's keys.
+var nameMapKeys = map[string]bool{
+	"properties": true, "patternProperties": true, "dependentSchemas": true,
+	"$defs": true, "definitions": true, "schemas": true,
+}
+
+// normalizeOpenAPI31 rewrites the OpenAPI 3.1 (JSON Schema 2020-12) constructs that the 3.0
+// loader cannot read into their 3.0 equivalents:
+//   - type: [T, "null"] becomes type: T with nullable: true (other type arrays are kept as unions),
+//   - numeric exclusiveMinimum/exclusiveMaximum become minimum/maximum with the boolean flag,
+//   - examples arrays provide example when it is missing,
+//   - $defs are hoisted into components.schemas and refs to them rewritten.
+//
+// const and prefixItems are left in place; the generator reads them from schema extensions.
+func normalizeOpenAPI31(jsonData []byte) ([]byte, []string, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(jsonData, &doc); err != nil {
+		return nil, nil, fmt.Errorf("parse json failed: %w", err)
+	}
+
+	var warnings []string
+	if webhooks, ok := doc["webhooks"].(map[string]any); ok && len(webhooks) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d webhook(s) ignored: only paths are generated", len(webhooks)))
+	}
+
+	hoistDefs(doc)
+	normalizeSchemaKeywords(doc, "")
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encode openapi 3.1 document failed: %w", err)
+	}
+	return data, warnings, nil
+}
+
+func normalizeSchemaKeywords(node any, parentKey string) {
+	switch value := node.(type) {
+	case map[string]any:
+		if !nameMapKeys[parentKey] {
+			normalizeTypeArray(value)
+			normalizeExclusiveBound(value, "exclusiveMinimum", "minimum")
+			normalizeExclusiveBound(value, "exclusiveMaximum", "maximum")
+			if examples, ok := value["examples"].([]any); ok && len(examples) > 0 {
+				if _, exists := value["example"]; !exists {
+					value["example"] = examples[0]
+				}
+				delete(value, "examples")
+			}
+		}
+		for key, child := range value {
+			normalizeSchemaKeywords(child, key)
+		}
+	case []any:
+		for _, child := range value {
+			normalizeSchemaKeywords(child, parentKey)
+		}
+	}
+}
+
+func normalizeTypeArray(schema map[string]any) {
+	types, ok := schema["type"].([]any)
+	if !ok {
+		return
+	}
+	var nonNull []any
+	nullable := false
+	for _, typ := range types {
+		if typ == "null" {
+			nullable = true
+			continue
+		}
+		nonNull = append(nonNull, typ)
+	}
+	if nullable && len(nonNull) == 1 {
+		schema["type"] = nonNull[0]
+		schema["nullable"] = true
+	}
+}
+
+func normalizeExclusiveBound(schema map[string]any, exclusiveKey string, boundKey string) {
+	bound, ok := schema[exclusiveKey].(float64)
+	if !ok {
+		return
+	}
+	schema[boundKey] = bound
+	schema[exclusiveKey] = true
+}
+
+type schemaDef struct {
+	owner   map[string]any
+	pointer string
+	name    string
+	value   any
+}
+
+// hoistDefs moves every $defs entry into components.schemas. The component keeps the def name
+// unless it is taken, in which case the owning component's name is prepended.
+func hoistDefs(doc map[string]any) {
+	var defs []schemaDef
+	collectDefs(doc, "", "", &defs)
+	if len(defs) == 0 {
+		return
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].pointer < defs[j].pointer })
+
+	components, _ := doc["components"].(map[string]any)
+	if components == nil {
+		components = map[string]any{}
+		doc["components"] = components
+	}
+	schemas, _ := components["schemas"].(map[string]any)
+	if schemas == nil {
+		schemas = map[string]any{}
+		components["schemas"] = schemas
+	}
+
+	refs := map[string]string{}
+	shortRefs := map[string]string{}
+	shortCounts := map[string]int{}
+	for _, def := range defs {
+		name := def.name
+		if _, taken := schemas[name]; taken {
+			name = ownerComponentName(def.pointer) + upperFirstASCII(def.name)
+			for idx := 2; schemas[name] != nil; idx++ {
+				name = fmt.Sprintf("%s%s%d", ownerComponentName(def.pointer), upperFirstASCII(def.name), idx)
+			}
+		}
+		schemas[name] = def.value
+		ref := "#/components/schemas/" + escapePointerToken(name)
+		refs["#"+def.pointer+"/$defs/"+escapePointerToken(def.name)] = ref
+		shortRefs["#/$defs/"+escapePointerToken(def.name)] = ref
+		shortCounts[def.name]++
+	}
+	for _, def := range defs {
+		delete(def.owner, "$defs")
+		if shortCounts[def.name] > 1 {
+			delete(shortRefs, "#/$defs/"+escapePointerToken(def.name))
+		}
+	}
+	rewriteRefs(doc, refs, shortRefs)
+}
+
+func collectDefs(node any, pointer string, parentKey string, defs *[]schemaDef) {
+	switch value := node.(type) {
+	case map[string]any:
+		if entries, ok := value["$defs"].(map[string]any); ok && !nameMapKeys[parentKey] {
+			for name, def := range entries {
+				*defs = append(*defs, schemaDef{owner: value, pointer: pointer, name: name, value: def})
+			}
+		}
+		for key, child := range value {
+			collectDefs(child, pointer+"/"+escapePointerToken(key), key, defs)
+		}
+	case []any:
+		for idx, child := range value {
+			collectDefs(child, fmt.Sprintf("%s/%d", pointer, idx), parentKey, defs)
+		}
+	}
+}
+
+func rewriteRefs(node any, refs map[string]string, shortRefs map[string]string) {
+	switch value := node.(type) {
+	case map[string]any:
+		if ref, ok := value["$ref"].(string); ok {
+			if target, found := refs[ref]; found {
+				value["$ref"] = target
+			} else if target, found := shortRefs[ref]; found {
+				value["$ref"] = target
+			}
+		}
+		for _, child := range value {
+			rewriteRefs(child, refs, shortRefs)
+		}
+	case []any:
+		for _, child := range value {
+			rewriteRefs(child, refs, shortRefs)
+		}
+	}
+}
+
+// ownerComponentName returns the component that declares a $defs block, e.g. "Order" for
+// /components/schemas/Order/properties/items.
+func ownerComponentName(pointer string) string {
+	const prefix = "/components/schemas/"
+	if !strings.HasPrefix(pointer, prefix) {
+		return "Def"
+	}
+	name, _, _ := strings.Cut(strings.TrimPrefix(pointer, prefix), "/")
+	return upperFirstASCII(unescapePointerToken(name))
+}
+
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func unescapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
+
+func upperFirstASCII(name string) string {
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		return name
+	}
+	return string(name[0]-'a'+'A') + name[1:]
+}
@@ -0,0 +1,65 @@
+package loader
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad_NormalizesOpenAPI31Schemas(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFile(t, dir, "openapi.yaml", `
+openapi: 3.1.0
+info: {title: t, version: '1'}
+webhooks:
+  ping: {post: {responses: {'200': {description: ok}}}}
+paths: {}
+components:
+  schemas:
+    Line:
+      type: string
+    Order:
+      type: object
+      $defs:
+        Line:
+          type: object
+        Note:
+          type: [string, 'null']
+          examples: [hello]
+      properties:
+        lines: {type: array, items: {$ref: '#/components/schemas/Order/$defs/Line'}}
+        note: {$ref: '#/$defs/Note'}
+        amount: {type: number, exclusiveMinimum: 0}
+        id: {type: [string, integer]}
+`)
+
+	doc, meta, err := Load(filepath.Join(dir, "openapi.yaml"), Options{})
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if meta.Version != "OpenAPI 3.1" || len(meta.Warnings) != 1 || !strings.Contains(meta.Warnings[0], "webhook") {
+		t.Fatalf("unexpected meta: %+v", meta)
+	}
+
+	order := doc.Components.Schemas["Order"].Value
+	if ref := order.Properties["lines"].Value.Items.Ref; ref != "#/components/schemas/OrderLine" {
+		t.Fatalf("taken $defs name should be prefixed with the owner, got %q", ref)
+	}
+	note := doc.Components.Schemas["Note"]
+	if order.Properties["note"].Ref != "#/components/schemas/Note" || note == nil {
+		t.Fatalf("short $defs ref should point at the hoisted schema, got %q", order.Properties["note"].Ref)
+	}
+	if !note.Value.Type.Is("string") || !note.Value.Nullable || note.Value.Example != "hello" {
+		t.Fatalf("[string, null] should become a nullable string with example: %+v", note.Value)
+	}
+	amount := order.Properties["amount"].Value
+	if amount.Min == nil || *amount.Min != 0 || !amount.ExclusiveMin {
+		t.Fatalf("numeric exclusiveMinimum should become minimum + flag: %+v", amount)
+	}
+	if got := order.Properties["id"].Value.Type.Slice(); len(got) != 2 {
+		t.Fatalf("non-null type arrays should be kept: %v", got)
+	}
+	if _, ok := order.Extensions["$defs"]; ok {
+		t.Fatal("$defs should be removed after hoisting")
+	}
+}
@@ -0,0 +1,199 @@
+package loader
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const defaultTimeout = 20 * time.Second
+
+// maxRedirects is the redirect limit of net/http's default policy.
+const maxRedirects = 10
+
+// Options configures how remote specs are fetched; the fetch settings are ignored for local files.
+// The zero value keeps the plain client with a 20s timeout.
+// Header values, the basic auth credentials and the bearer token are expanded with os.ExpandEnv.
+type Options struct {
+	// AllowUnresolvedRefs drops local $refs that point at nothing instead of failing and lists
+	// them in Meta.UnresolvedRefs, so lint can report every broken ref of a spec.
+	AllowUnresolvedRefs bool
+
+	Headers     map[string]string
+	BasicAuth   *BasicAuth
+	BearerToken string
+	// CAFile is a PEM bundle trusted in addition to the system roots.
+	CAFile string
+	// Insecure skips TLS certificate verification.
+	Insecure bool
+	// Proxy is the proxy URL; empty falls back to HTTP_PROXY/HTTPS_PROXY/NO_PROXY.
+	Proxy   string
+	Timeout time.Duration
+	// MaxBodySize limits the response body in bytes; zero means unlimited.
+	MaxBodySize int64
+}
+
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+func (o Options) client() (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if proxy := strings.TrimSpace(o.Proxy); proxy != "" {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy url %q", redactURL(proxy))
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if o.CAFile != "" || o.Insecure {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: o.Insecure}
+		if o.CAFile != "" {
+			pem, err := os.ReadFile(o.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("read ca file failed: %w", err)
+			}
+			pool, err := x509.SystemCertPool()
+			if err != nil || pool == nil {
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("ca file %s contains no PEM certificates", o.CAFile)
+			}
+			tlsConfig.RootCAs = pool
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	timeout := o.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &http.Client{Timeout: timeout, Transport: transport, CheckRedirect: o.checkRedirect}, nil
+}
+
+// checkRedirect keeps the configured headers and credentials on the origin of the first request,
+// as refReader does for external $refs. net/http only drops the headers it knows, such as
+// Authorization, and only when the host changes, so custom credential headers like X-Api-Key
+// would otherwise follow a redirect to another origin.
+func (o Options) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	if sameOrigin(req.URL, via[0].URL) {
+		return nil
+	}
+	for name := range o.Headers {
+		req.Header.Del(name)
+	}
+	req.Header.Del("Authorization")
+	return nil
+}
+
+// withoutCredentials drops the headers and credentials, for requests to hosts other than the
+// one serving the spec.
+func (o Options) withoutCredentials() Options {
+	o.Headers = nil
+	o.BasicAuth = nil
+	o.BearerToken = ""
+	return o
+}
+
+// newRequest builds a GET request carrying the configured headers and credentials.
+func (o Options) newRequest(ctx context.Context, rawURL string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request for %s failed", redactURL(rawURL))
+	}
+	for name, value := range o.Headers {
+		req.Header.Set(name, os.ExpandEnv(value))
+	}
+	if o.BasicAuth != nil {
+		req.SetBasicAuth(os.ExpandEnv(o.BasicAuth.Username), os.ExpandEnv(o.BasicAuth.Password))
+	}
+	if token := os.ExpandEnv(o.BearerToken); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req, nil
+}
+
+// do sends req and wraps transport errors without the request URL, which may carry credentials.
+func (o Options) do(req *http.Request) (*http.Response, error) {
+	client, err := o.client()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("fetch %s failed: %w", redactURL(req.URL.String()), err)
+	}
+	return resp, nil
+}
+
+// readBody reads the response body, enforcing MaxBodySize.
+func (o Options) readBody(body io.Reader) ([]byte, error) {
+	if o.MaxBodySize <= 0 {
+		return io.ReadAll(body)
+	}
+	data, err := io.ReadAll(io.LimitReader(body, o.MaxBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > o.MaxBodySize {
+		return nil, fmt.Errorf("response exceeds max body size of %d bytes", o.MaxBodySize)
+	}
+	return data, nil
+}
+
+// sensitiveQueryKeys are query parameters whose values are masked in reported URLs.
+var sensitiveQueryKeys = []string{"token", "access_token", "api_key", "apikey", "key", "password", "secret", "signature", "sig"}
+
+// RedactSource returns input safe for logs: URLs are redacted, stdin is reported as <stdin>
+// and file paths are returned unchanged.
+func RedactSource(input string) string {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == StdinInput {
+		return stdinSource
+	}
+	if isURL(trimmed) {
+		return redactURL(trimmed)
+	}
+	return trimmed
+}
+
+// redactURL removes user info and masks credential-like query values so URLs can be shown safely.
+func redactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "<invalid url>"
+	}
+	parsed.User = nil
+	if parsed.RawQuery != "" {
+		query := parsed.Query()
+		for key := range query {
+			for _, sensitive := range sensitiveQueryKeys {
+				if strings.EqualFold(key, sensitive) {
+					query.Set(key, "REDACTED")
+					break
+				}
+			}
+		}
+		parsed.RawQuery = query.Encode()
+	}
+	return parsed.String()
+}
@@ -0,0 +1,103 @@
+package loader
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const minimalSpec = `{"openapi":"3.0.0","info":{"title":"t","version":"1"},"paths":{}}`
+
+func TestLoad_URLSendsHeadersAndCredentials(t *testing.T) {
+	t.Setenv("SPEC_TOKEN", "s3cret")
+	var gotHeader, gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Tenant")
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(minimalSpec))
+	}))
+	defer server.Close()
+
+	opts := Options{Headers: map[string]string{"X-Tenant": "acme"}, BearerToken: "${SPEC_TOKEN}"}
+	_, meta, err := Load(server.URL+"/doc.json?token=abc", opts)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if gotHeader != "acme" || gotAuth != "Bearer s3cret" {
+		t.Fatalf("unexpected request headers: X-Tenant=%q Authorization=%q", gotHeader, gotAuth)
+	}
+	if strings.Contains(meta.Source, "abc") || !strings.Contains(meta.Source, "token=REDACTED") {
+		t.Fatalf("source should be redacted: %s", meta.Source)
+	}
+
+	if _, _, err := Load(server.URL, Options{BasicAuth: &BasicAuth{Username: "bob", Password: "${SPEC_TOKEN}"}}); err != nil {
+		t.Fatalf("Load with basic auth returned error: %v", err)
+	}
+	if user, pass, ok := (&http.Request{Header: http.Header{"Authorization": {gotAuth}}}).BasicAuth(); !ok || user != "bob" || pass != "s3cret" {
+		t.Fatalf("unexpected basic auth: %q", gotAuth)
+	}
+}
+
+func TestLoad_ErrorsNeverContainCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(minimalSpec))
+	}))
+	defer server.Close()
+
+	withUser := strings.Replace(server.URL, "http://", "http://bob:hunter2@", 1)
+	_, _, err := Load(withUser+"/missing?api_key=k3y", Options{})
+	if err == nil || !strings.Contains(err.Error(), "status 401") {
+		t.Fatalf("expected status error, got %v", err)
+	}
+	if strings.Contains(err.Error(), "hunter2") || strings.Contains(err.Error(), "k3y") {
+		t.Fatalf("error leaks credentials: %v", err)
+	}
+
+	_, _, err = Load(server.URL, Options{MaxBodySize: 10})
+	if err == nil || !strings.Contains(err.Error(), "max body size of 10 bytes") {
+		t.Fatalf("expected max body size error, got %v", err)
+	}
+}
+
+func TestLoad_RedirectToOtherOriginDropsHeadersAndCredentials(t *testing.T) {
+	var gotHeader, gotAuth string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Api-Key")
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(minimalSpec))
+	}))
+	defer target.Close()
+	var sameOriginHeader string
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/moved":
+			http.Redirect(w, r, "/doc.json", http.StatusFound)
+		case "/doc.json":
+			sameOriginHeader = r.Header.Get("X-Api-Key")
+			_, _ = w.Write([]byte(minimalSpec))
+		default:
+			http.Redirect(w, r, target.URL+"/doc.json", http.StatusFound)
+		}
+	}))
+	defer origin.Close()
+
+	opts := Options{Headers: map[string]string{"X-Api-Key": "k3y"}, BearerToken: "s3cret"}
+	if _, _, err := Load(origin.URL+"/elsewhere", opts); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if gotHeader != "" || gotAuth != "" {
+		t.Fatalf("credentials followed a redirect to another origin: X-Api-Key=%q Authorization=%q", gotHeader, gotAuth)
+	}
+
+	if _, _, err := Load(origin.URL+"/moved", opts); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if sameOriginHeader != "k3y" {
+		t.Fatalf("a same-origin redirect should keep the headers, got X-Api-Key=%q", sameOriginHeader)
+	}
+}
@@ -0,0 +1,92 @@
+package loader
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Revision identifies one version of an input without parsing it: HTTP validators and a body
+// digest for URLs, modification time and size for files.
+type Revision struct {
+	ETag         string
+	LastModified string
+	Digest       string
+	ModTime      time.Time
+	Size         int64
+	// Body is the spec fetched from a URL, kept so LoadData can reuse it instead of
+	// downloading it again. It is nil for files.
+	Body []byte
+}
+
+// Poll reports whether input changed since prev. URLs are fetched with If-None-Match and
+// If-Modified-Since so servers that support validators answer 304 without a body.
+// The zero Revision always counts as changed.
+func Poll(input string, prev Revision, opts Options) (Revision, bool, error) {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == "" {
+		return prev, false, errors.New("input is empty")
+	}
+	if trimmed == StdinInput {
+		return prev, false, errors.New("stdin input cannot be polled for changes")
+	}
+
+	if isURL(trimmed) {
+		return pollURL(context.Background(), trimmed, prev, opts)
+	}
+
+	info, err := os.Stat(trimmed)
+	if err != nil {
+		return prev, false, fmt.Errorf("stat file failed: %w", err)
+	}
+	next := Revision{ModTime: info.ModTime(), Size: info.Size()}
+	return next, !next.ModTime.Equal(prev.ModTime) || next.Size != prev.Size, nil
+}
+
+func pollURL(ctx context.Context, rawURL string, prev Revision, opts Options) (Revision, bool, error) {
+	req, err := opts.newRequest(ctx, rawURL)
+	if err != nil {
+		return prev, false, err
+	}
+	if prev.ETag != "" {
+		req.Header.Set("If-None-Match", prev.ETag)
+	}
+	if prev.LastModified != "" {
+		req.Header.Set("If-Modified-Since", prev.LastModified)
+	}
+
+	resp, err := opts.do(req)
+	if err != nil {
+		return prev, false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		return prev, false, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return prev, false, fmt.Errorf("fetch %s failed: status %d", redactURL(rawURL), resp.StatusCode)
+	}
+
+	body, err := opts.readBody(resp.Body)
+	if err != nil {
+		return prev, false, fmt.Errorf("read %s response failed: %w", redactURL(rawURL), err)
+	}
+	digest := sha256.Sum256(body)
+	next := Revision{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Digest:       hex.EncodeToString(digest[:]),
+		Body:         body,
+	}
+	return next, next.Digest != prev.Digest, nil
+}
+
+func isURL(input string) bool {
+	return strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://")
+}
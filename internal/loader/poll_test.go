@@ -0,0 +1,62 @@
+package loader
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPoll_URLUsesValidators(t *testing.T) {
+	body := `{"swagger":"2.0"}`
+	var conditional int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` && body == `{"swagger":"2.0"}` {
+			conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	first, changed, err := Poll(server.URL, Revision{}, Options{})
+	if err != nil || !changed || first.ETag != `"v1"` || string(first.Body) != body {
+		t.Fatalf("first poll: revision=%+v changed=%t err=%v", first, changed, err)
+	}
+	second, changed, err := Poll(server.URL, first, Options{})
+	if err != nil || changed || conditional != 1 || second.Digest != first.Digest || string(second.Body) != body {
+		t.Fatalf("second poll should be answered with 304 and keep the body: changed=%t conditional=%d err=%v", changed, conditional, err)
+	}
+
+	body = `{"swagger":"2.0","info":{}}`
+	if _, changed, err := Poll(server.URL, second, Options{}); err != nil || !changed {
+		t.Fatalf("changed body should be detected: changed=%t err=%v", changed, err)
+	}
+}
+
+func TestPoll_FileUsesModTimeAndSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "doc.json")
+	if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+		t.Fatalf("write spec failed: %v", err)
+	}
+
+	first, changed, err := Poll(path, Revision{}, Options{})
+	if err != nil || !changed {
+		t.Fatalf("first poll: changed=%t err=%v", changed, err)
+	}
+	if _, changed, _ := Poll(path, first, Options{}); changed {
+		t.Fatalf("untouched file should not be reported as changed")
+	}
+
+	later := first.ModTime.Add(time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("touch spec failed: %v", err)
+	}
+	if _, changed, _ := Poll(path, first, Options{}); !changed {
+		t.Fatalf("touched file should be reported as changed")
+	}
+}
@@ -0,0 +1,354 @@
+package loader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// refReader reads the external documents a spec refers to, once per location.
+type refReader struct {
+	ctx  context.Context
+	opts Options
+	// root is the location of the spec itself. Credentials are only sent to its origin and a
+	// remote root may not refer to local files.
+	root  *url.URL
+	cache map[string][]byte
+	files []string
+}
+
+// newLoader returns a kin loader that follows relative external $refs, fetching remote
+// documents with the same options as the root spec.
+func (r *refReader) newLoader() *openapi3.Loader {
+	loader := openapi3.NewLoader()
+	loader.Context = r.ctx
+	loader.IsExternalRefsAllowed = true
+	r.cache = map[string][]byte{}
+	loader.ReadFromURIFunc = func(_ *openapi3.Loader, location *url.URL) ([]byte, error) {
+		key := location.String()
+		if data, ok := r.cache[key]; ok {
+			return data, nil
+		}
+		data, err := r.read(location)
+		if err != nil {
+			return nil, err
+		}
+		r.cache[key] = data
+		return data, nil
+	}
+	return loader
+}
+
+func (r *refReader) read(location *url.URL) ([]byte, error) {
+	if isRemote(location) {
+		ref := *location
+		ref.Fragment = ""
+		opts := r.opts
+		if !sameOrigin(&ref, r.root) {
+			opts = opts.withoutCredentials()
+		}
+		data, _, err := fetchURL(r.ctx, ref.String(), opts)
+		return data, err
+	}
+	if location.Scheme != "" && location.Scheme != "file" {
+		return nil, fmt.Errorf("unsupported external ref scheme %q", location.Scheme)
+	}
+	if isRemote(r.root) {
+		return nil, fmt.Errorf("external ref %s: a remote spec cannot refer to local files", location.Path)
+	}
+	data, err := os.ReadFile(location.Path)
+	if err != nil {
+		return nil, fmt.Errorf("read external ref failed: %w", err)
+	}
+	r.files = append(r.files, filepath.FromSlash(location.Path))
+	return toJSON(data)
+}
+
+func isRemote(location *url.URL) bool {
+	return location != nil && (location.Scheme == "http" || location.Scheme == "https")
+}
+
+// sameOrigin reports whether a and b share scheme, host and port, with default ports filled in.
+func sameOrigin(a *url.URL, b *url.URL) bool {
+	if !isRemote(a) || !isRemote(b) {
+		return false
+	}
+	return strings.EqualFold(a.Scheme, b.Scheme) &&
+		strings.EqualFold(a.Hostname(), b.Hostname()) &&
+		originPort(a) == originPort(b)
+}
+
+func originPort(location *url.URL) string {
+	if port := location.Port(); port != "" {
+		return port
+	}
+	if strings.EqualFold(location.Scheme, "https") {
+		return "443"
+	}
+	return "80"
+}
+
+// localFiles returns the local files read so far, sorted and without duplicates.
+func (r *refReader) localFiles() []string {
+	files := append([]string(nil), r.files...)
+	sort.Strings(files)
+	unique := files[:0]
+	for _, file := range files {
+		if len(unique) == 0 || unique[len(unique)-1] != file {
+			unique = append(unique, file)
+		}
+	}
+	return unique
+}
+
+// internalizeRefs moves every externally referenced component into the root components so the
+// generator only sees local #/components/... refs.
+func internalizeRefs(doc *openapi3.T) {
+	names := rootComponentRefs(doc)
+	taken := map[string]bool{}
+	doc.InternalizeRefs(context.Background(), func(doc *openapi3.T, ref openapi3.ComponentRef) string {
+		if name, ok := openapi3.ReferencesComponentInRootDocument(doc, ref); ok {
+			return path.Base(name)
+		}
+
+		key := componentRefKey(ref)
+		if name, ok := names[key]; ok {
+			return name
+		}
+
+		name := externalRefName(ref)
+		if taken[ref.CollectionName()+" "+name] || componentExists(doc, ref.CollectionName(), name) {
+			name = openapi3.DefaultRefNameResolver(doc, ref)
+		}
+		names[key] = name
+		taken[ref.CollectionName()+" "+name] = true
+		return name
+	})
+}
+
+// rootComponentRefs maps root components that are themselves external refs (User: {$ref: ./user.yaml})
+// to their names, so other refs to the same file reuse the component. InternalizeRefs clears the
+// top-level refs before it visits the paths, which would otherwise hide the match.
+func rootComponentRefs(doc *openapi3.T) map[string]string {
+	names := map[string]string{}
+	if doc.Components == nil {
+		return names
+	}
+	add := func(name string, ref openapi3.ComponentRef) {
+		if ref.RefString() != "" && !strings.HasPrefix(ref.RefString(), "#") {
+			names[componentRefKey(ref)] = name
+		}
+	}
+	for name, ref := range doc.Components.Schemas {
+		add(name, ref)
+	}
+	for name, ref := range doc.Components.Parameters {
+		add(name, ref)
+	}
+	for name, ref := range doc.Components.RequestBodies {
+		add(name, ref)
+	}
+	for name, ref := range doc.Components.Responses {
+		add(name, ref)
+	}
+	return names
+}
+
+func componentRefKey(ref openapi3.ComponentRef) string {
+	if refPath := ref.RefPath(); refPath != nil {
+		return ref.CollectionName() + " " + refPath.String()
+	}
+	return ref.CollectionName() + " " + ref.RefString()
+}
+
+// externalRefName derives a component name from the referenced component, or from the file
+// name when the whole file is the component: ./schemas/user.yaml -> user,
+// ./common.yaml#/components/schemas/Address -> Address.
+func externalRefName(ref openapi3.ComponentRef) string {
+	raw := ref.RefString()
+	if refPath := ref.RefPath(); refPath != nil {
+		raw = refPath.Path
+		if refPath.Fragment != "" {
+			raw += "#" + refPath.Fragment
+		}
+	}
+
+	file, fragment, _ := strings.Cut(raw, "#")
+	name := path.Base(strings.TrimRight(fragment, "/"))
+	if fragment == "" || name == "/" || name == "." {
+		name = path.Base(file)
+		for ext := path.Ext(name); ext != ""; ext = path.Ext(name) {
+			name = strings.TrimSuffix(name, ext)
+		}
+	}
+	return openapi3.InvalidIdentifierCharRegExp.ReplaceAllString(name, "_")
+}
+
+func componentExists(doc *openapi3.T, collection string, name string) bool {
+	if doc.Components == nil {
+		return false
+	}
+	var found bool
+	switch collection {
+	case "schemas":
+		_, found = doc.Components.Schemas[name]
+	case "parameters":
+		_, found = doc.Components.Parameters[name]
+	case "headers":
+		_, found = doc.Components.Headers[name]
+	case "requestBodies":
+		_, found = doc.Components.RequestBodies[name]
+	case "responses":
+		_, found = doc.Components.Responses[name]
+	case "securitySchemes":
+		_, found = doc.Components.SecuritySchemes[name]
+	case "examples":
+		_, found = doc.Components.Examples[name]
+	case "links":
+		_, found = doc.Components.Links[name]
+	case "callbacks":
+		_, found = doc.Components.Callbacks[name]
+	}
+	return found
+}
+
+// UnresolvedRef is a $ref into the spec itself that points at nothing.
+type UnresolvedRef struct {
+	Ref string
+	// Pointer is the JSON pointer of the object holding the $ref, e.g. /paths/~1users/get.
+	Pointer string
+}
+
+// UnresolvedRefError lists every local $ref of a spec that points at nothing, in document order
+// with object keys sorted.
+type UnresolvedRefError struct {
+	Refs []UnresolvedRef
+}
+
+func (e *UnresolvedRefError) Error() string {
+	first := e.Refs[0]
+	message := fmt.Sprintf("failed to resolve $ref %q at %s", first.Ref, first.Pointer)
+	if len(e.Refs) > 1 {
+		message += fmt.Sprintf(" (and %d more unresolved refs)", len(e.Refs)-1)
+	}
+	return message
+}
+
+// explainLoadError replaces a failed load's error with an UnresolvedRefError when a #/... $ref
+// of the spec does not resolve, since kin reports those only by the missing map key, e.g.
+// `map key "components" not found`, without naming the $ref. Other errors are kept.
+func explainLoadError(jsonData []byte, err error) error {
+	var root any
+	if json.Unmarshal(jsonData, &root) != nil {
+		return err
+	}
+	var missing []UnresolvedRef
+	collectUnresolvedRefs(root, root, "", &missing)
+	if len(missing) == 0 {
+		return err
+	}
+	return &UnresolvedRefError{Refs: missing}
+}
+
+// dropUnresolvedRefs removes the $ref of every object whose #/... ref does not resolve, leaving
+// its sibling keys, and returns the rewritten spec with the dropped refs.
+func dropUnresolvedRefs(jsonData []byte) ([]byte, []UnresolvedRef, error) {
+	var root any
+	if err := json.Unmarshal(jsonData, &root); err != nil {
+		return nil, nil, fmt.Errorf("parse json failed: %w", err)
+	}
+	var missing []UnresolvedRef
+	collectUnresolvedRefs(root, root, "", &missing)
+	if len(missing) == 0 {
+		return jsonData, nil, nil
+	}
+	// Refs are checked against the original tree above, so dropping one cannot hide another.
+	removeRefs(root, missing)
+	rewritten, err := json.Marshal(root)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encode json failed: %w", err)
+	}
+	return rewritten, missing, nil
+}
+
+func removeRefs(root any, refs []UnresolvedRef) {
+	for _, ref := range refs {
+		node := root
+		if ref.Pointer != "/" {
+			for _, token := range strings.Split(ref.Pointer[1:], "/") {
+				node = pointerChild(node, unescapePointerToken(token))
+			}
+		}
+		if object, ok := node.(map[string]any); ok {
+			delete(object, "$ref")
+		}
+	}
+}
+
+func pointerChild(node any, token string) any {
+	switch value := node.(type) {
+	case map[string]any:
+		return value[token]
+	case []any:
+		if idx, err := strconv.Atoi(token); err == nil && idx >= 0 && idx < len(value) {
+			return value[idx]
+		}
+	}
+	return nil
+}
+
+func collectUnresolvedRefs(root any, node any, pointer string, missing *[]UnresolvedRef) {
+	switch value := node.(type) {
+	case map[string]any:
+		if ref, ok := value["$ref"].(string); ok && strings.HasPrefix(ref, "#") && !resolvesPointer(root, ref) {
+			where := pointer
+			if where == "" {
+				where = "/"
+			}
+			*missing = append(*missing, UnresolvedRef{Ref: ref, Pointer: where})
+		}
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			collectUnresolvedRefs(root, value[key], pointer+"/"+escapePointerToken(key), missing)
+		}
+	case []any:
+		for idx, item := range value {
+			collectUnresolvedRefs(root, item, pointer+"/"+strconv.Itoa(idx), missing)
+		}
+	}
+}
+
+// resolvesPointer reports whether the fragment ref, e.g. #/components/schemas/User, points at a
+// value of root.
+func resolvesPointer(root any, ref string) bool {
+	fragment, err := url.PathUnescape(strings.TrimPrefix(ref, "#"))
+	if err != nil {
+		return false
+	}
+	if fragment == "" {
+		return true
+	}
+	if !strings.HasPrefix(fragment, "/") {
+		return false
+	}
+	node := root
+	for _, token := range strings.Split(fragment[1:], "/") {
+		if node = pointerChild(node, unescapePointerToken(token)); node == nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+// Package surface describes the TypeScript API a generation run emits: the exported functions
+// with their signatures and the shapes of the model types. It is persisted as the API manifest
+// and compared by the diff package.
+package surface
+
+// Surface lists the functions (by group and emission order) and types (by group and name).
+type Surface struct {
+	Functions []Function `json:"functions"`
+	Types     []Type     `json:"types"`
+}
+
+// Function is one generated API function.
+type Function struct {
+	Group  string `json:"group"`
+	Name   string `json:"name"`
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Args are in signature order: path params, data, params, headers.
+	Args    []Field `json:"args,omitempty"`
+	Returns string  `json:"returns"`
+}
+
+// Type is one model type of a group. Interfaces list their fields; aliases carry the aliased
+// type expression.
+type Type struct {
+	Group   string   `json:"group"`
+	Name    string   `json:"name"`
+	Kind    string   `json:"kind"`
+	Extends []string `json:"extends,omitempty"`
+	Fields  []Field  `json:"fields,omitempty"`
+	Alias   string   `json:"alias,omitempty"`
+}
+
+// Field is a function argument or an interface property. An index signature is reported
+// with the name IndexSignature.
+type Field struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+// Type kinds.
+const (
+	Interface = "interface"
+	Alias     = "alias"
+)
+
+// IndexSignature names the [key: string] member of an interface.
+const IndexSignature = "[key: string]"
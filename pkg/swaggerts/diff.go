@@ -0,0 +1,34 @@
+package swaggerts
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gopkg-dev/swagger-ts-gen/internal/diff"
+	"github.com/gopkg-dev/swagger-ts-gen/internal/generator"
+)
+
+// DiffAPI compares the functions and types generated from two sources with the same options,
+// without rendering any file. Function changes come first in path order, then type changes in
+// group and name order; count the breaking ones with BreakingAPIChanges.
+func DiffAPI(before Source, after Source, opts Options) ([]APIChange, error) {
+	old, err := generator.NewFromSources([]Source{before}, opts).Surface()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", before.Name, err)
+	}
+	current, err := generator.NewFromSources([]Source{after}, opts).Surface()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", after.Name, err)
+	}
+	return diff.Compare(old, current), nil
+}
+
+// APIChangeFormats lists the formats WriteAPIChanges accepts.
+func APIChangeFormats() []string {
+	return append([]string(nil), diff.Formats...)
+}
+
+// WriteAPIChanges writes changes as text, json or markdown.
+func WriteAPIChanges(w io.Writer, format string, changes []APIChange) error {
+	return diff.Write(w, format, changes)
+}
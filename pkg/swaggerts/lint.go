@@ -0,0 +1,63 @@
+package swaggerts
+
+import (
+	"io"
+
+	"github.com/gopkg-dev/swagger-ts-gen/internal/lint"
+	"github.com/gopkg-dev/swagger-ts-gen/internal/loader"
+)
+
+type (
+	// LintOptions configures Lint. Its Generator field takes the Options of a generation run so
+	// generator-aware rules see the same groups and return types.
+	LintOptions = lint.Options
+	// LintFinding is one reported problem, located by a JSON pointer into the OpenAPI 3 document.
+	LintFinding  = lint.Finding
+	LintSeverity = lint.Severity
+	LintRule     = lint.Rule
+	// UnresolvedRef is a local $ref dropped by a load with LoadOptions.AllowUnresolvedRefs; pass
+	// Meta.UnresolvedRefs in LintOptions.UnresolvedRefs to report them.
+	UnresolvedRef = loader.UnresolvedRef
+)
+
+const (
+	LintSeverityOff     = lint.SeverityOff
+	LintSeverityInfo    = lint.SeverityInfo
+	LintSeverityWarning = lint.SeverityWarning
+	LintSeverityError   = lint.SeverityError
+)
+
+// IDs of the lint rules, see LintRules.
+const (
+	LintRuleSpecValidation        = lint.RuleSpecValidation
+	LintRuleMissingOperationID    = lint.RuleMissingOperationID
+	LintRuleDuplicateFunctionName = lint.RuleDuplicateFunctionName
+	LintRuleUnresolvedRef         = lint.RuleUnresolvedRef
+	LintRuleEmptyDataSchema       = lint.RuleEmptyDataSchema
+	LintRuleMissingPathParam      = lint.RuleMissingPathParam
+)
+
+// Lint runs every enabled rule over the sources. Findings are sorted by source, pointer and rule.
+func Lint(sources []Source, opts LintOptions) ([]LintFinding, error) {
+	return lint.Lint(sources, opts)
+}
+
+// LintRules lists every rule with its default severity, in reporting order.
+func LintRules() []LintRule {
+	return append([]LintRule(nil), lint.Rules...)
+}
+
+// ParseLintSeverity accepts off, info, warning and error.
+func ParseLintSeverity(value string) (LintSeverity, error) {
+	return lint.ParseSeverity(value)
+}
+
+// LintFormats lists the formats WriteLintFindings accepts.
+func LintFormats() []string {
+	return append([]string(nil), lint.Formats...)
+}
+
+// WriteLintFindings writes findings as text, json or sarif.
+func WriteLintFindings(w io.Writer, format string, findings []LintFinding) error {
+	return lint.Write(w, format, findings)
+}
@@ -0,0 +1,147 @@
+// Package swaggerts generates a typed TypeScript API client from Swagger 2.0 and OpenAPI 3.x
+// specs. It is the entry point for Go programs: Load, Generate and Render produce the client,
+// Lint checks specs for problems and DiffAPI compares the API of two specs. The swagger-ts
+// command, including its lint and diff subcommands, runs through the same functions.
+//
+//	spec, meta, err := swaggerts.Load(ctx, "docs/swagger.json", swaggerts.LoadOptions{})
+//	if err != nil {
+//		return err
+//	}
+//	files, report, err := swaggerts.Render(ctx, []swaggerts.Source{{Spec: spec, Name: meta.Source}}, swaggerts.Options{})
+//
+// Option and result types are aliases of the generator's own types, so every setting of the
+// command line and the config file is available here. The API is not stable yet: until a v1
+// release, those types may change in minor versions along with the generator.
+package swaggerts
+
+import (
+	"context"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/gopkg-dev/swagger-ts-gen/internal/diff"
+	"github.com/gopkg-dev/swagger-ts-gen/internal/generator"
+	"github.com/gopkg-dev/swagger-ts-gen/internal/loader"
+)
+
+type (
+	// LoadOptions configures how remote specs are fetched; they are ignored for local files.
+	LoadOptions = loader.Options
+	BasicAuth   = loader.BasicAuth
+	// Meta describes a loaded spec: where it came from, its version and the ignored parts.
+	Meta = loader.Meta
+
+	// Source is one spec of a generation run. Several sources share one output tree;
+	// Namespace and BaseURL keep their groups and requests apart.
+	Source = generator.Source
+	// Options configures generation. With Options.Check, Generate and Render only compare the
+	// output with Options.OutputDir and list the differences in Report.Changes.
+	Options           = generator.Options
+	Envelope          = generator.Envelope
+	Pagination        = generator.Pagination
+	PaginationProfile = generator.PaginationProfile
+	PaginationRule    = generator.PaginationRule
+	Grouping          = generator.Grouping
+	GroupRule         = generator.GroupRule
+	// TypeOverride replaces the generated type of a schema, see Options.TypeOverrides.
+	TypeOverride = generator.TypeOverride
+
+	// Report summarizes a generation run.
+	Report     = generator.Report
+	FileChange = generator.FileChange
+	ChangeKind = generator.ChangeKind
+	Fallback   = generator.Fallback
+	// StrictError is returned in strict mode when any schema fell back to any.
+	StrictError = generator.StrictError
+	// APIChange is a difference from the previous manifest, see Options.Manifest. Its Kind is
+	// one of the APIChange... constants.
+	APIChange = diff.Change
+)
+
+const (
+	// StdinInput is the input value that reads the spec from standard input.
+	StdinInput = loader.StdinInput
+
+	EnumStyleConst = generator.EnumStyleConst
+	EnumStyleEnum  = generator.EnumStyleEnum
+	EnumStyleUnion = generator.EnumStyleUnion
+
+	GroupByPath = generator.GroupByPath
+	GroupByTag  = generator.GroupByTag
+
+	PaginationKindPage   = generator.PaginationKindPage
+	PaginationKindCursor = generator.PaginationKindCursor
+
+	ChangeAdded   = generator.ChangeAdded
+	ChangeRemoved = generator.ChangeRemoved
+	ChangeChanged = generator.ChangeChanged
+
+	FallbackUnresolvedRef = generator.FallbackUnresolvedRef
+	FallbackEmptySchema   = generator.FallbackEmptySchema
+
+	ManifestFile  = generator.ManifestFile
+	ChangelogFile = generator.ChangelogFile
+)
+
+// Kinds of APIChange.
+const (
+	APIChangeFunctionAdded      = diff.FunctionAdded
+	APIChangeFunctionRemoved    = diff.FunctionRemoved
+	APIChangeFunctionRenamed    = diff.FunctionRenamed
+	APIChangeFunctionMoved      = diff.FunctionMoved
+	APIChangeArgumentAdded      = diff.ArgumentAdded
+	APIChangeArgumentRemoved    = diff.ArgumentRemoved
+	APIChangeReturnTypeChanged  = diff.ReturnTypeChanged
+	APIChangeTypeAdded          = diff.TypeAdded
+	APIChangeTypeRemoved        = diff.TypeRemoved
+	APIChangeTypeKindChanged    = diff.TypeKindChanged
+	APIChangeTypeExtendsChanged = diff.TypeExtendsChanged
+	APIChangeFieldAdded         = diff.FieldAdded
+	APIChangeFieldRemoved       = diff.FieldRemoved
+	APIChangeFieldRequired      = diff.FieldRequired
+	APIChangeFieldOptional      = diff.FieldOptional
+	APIChangeFieldTypeChanged   = diff.FieldTypeChanged
+	APIChangeEnumValueAdded     = diff.EnumValueAdded
+	APIChangeEnumValueRemoved   = diff.EnumValueRemoved
+)
+
+// Load reads a Swagger 2.0 or OpenAPI 3.x spec from a file path, an http(s) URL or StdinInput,
+// resolves its external $refs and converts it to OpenAPI 3. Cancelling ctx aborts remote fetches.
+func Load(ctx context.Context, input string, opts LoadOptions) (*openapi3.T, *Meta, error) {
+	return loader.LoadContext(ctx, input, opts)
+}
+
+// LoadData is Load for a spec whose bytes were already read from input, e.g. by a caller that
+// polls a URL for changes. input names the source and is the base URI of relative $refs.
+func LoadData(ctx context.Context, input string, data []byte, opts LoadOptions) (*openapi3.T, *Meta, error) {
+	return loader.LoadData(ctx, input, data, opts)
+}
+
+// Generate writes the client for sources to Options.OutputDir, or only compares it with the
+// files on disk when Options.Check is set. Cancellation is checked between groups and before
+// any file is written.
+func Generate(ctx context.Context, sources []Source, opts Options) (*Report, error) {
+	return generator.NewFromSources(sources, opts).GenerateContext(ctx)
+}
+
+// Render generates the client for sources in memory. Files are keyed by their path relative to
+// the output dir with forward slashes, e.g. "index.ts" or "users/model/index.ts". Nothing is
+// written; with Options.Manifest the previous manifest is still read from Options.OutputDir.
+func Render(ctx context.Context, sources []Source, opts Options) (map[string]string, *Report, error) {
+	return generator.NewFromSources(sources, opts).Render(ctx)
+}
+
+// BreakingAPIChanges counts the breaking changes.
+func BreakingAPIChanges(changes []APIChange) int {
+	return diff.Breaking(changes)
+}
+
+// DefaultScalarFormats returns the format mapping used when Options.Scalars does not override it.
+func DefaultScalarFormats() map[string]string {
+	return generator.DefaultScalarFormats()
+}
+
+// BuiltinPaginationProfiles returns the pagination profiles available without configuration.
+func BuiltinPaginationProfiles() []PaginationProfile {
+	return generator.BuiltinPaginationProfiles()
+}
@@ -0,0 +1,179 @@
+package swaggerts_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gopkg-dev/swagger-ts-gen/pkg/swaggerts"
+)
+
+const usersSpec = `openapi: 3.0.3
+info:
+  title: users
+  version: "1"
+paths:
+  /api/v1/users/{id}:
+    get:
+      operationId: getUser
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  success:
+                    type: boolean
+                  data:
+                    $ref: "#/components/schemas/User"
+components:
+  schemas:
+    User:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+`
+
+func loadUsers(t *testing.T) []swaggerts.Source {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(path, []byte(usersSpec), 0o644); err != nil {
+		t.Fatalf("write spec failed: %v", err)
+	}
+	spec, meta, err := swaggerts.Load(context.Background(), path, swaggerts.LoadOptions{})
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	return []swaggerts.Source{{Spec: spec, Name: meta.Source}}
+}
+
+func TestRender_ReturnsGeneratedFilesWithoutWriting(t *testing.T) {
+	sources := loadUsers(t)
+	outputDir := filepath.Join(t.TempDir(), "api")
+
+	files, report, err := swaggerts.Render(context.Background(), sources, swaggerts.Options{OutputDir: outputDir})
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if report.Operations != 1 || report.Written != 0 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if _, err := os.Stat(outputDir); !os.IsNotExist(err) {
+		t.Fatalf("Render should not create the output dir: %v", err)
+	}
+	if !strings.Contains(files["users/index.ts"], "export async function getUser(") {
+		t.Fatalf("missing getUser in users/index.ts:\n%s", files["users/index.ts"])
+	}
+	if !strings.Contains(files["users/model/index.ts"], "export interface User {") {
+		t.Fatalf("missing User in users/model/index.ts:\n%s", files["users/model/index.ts"])
+	}
+
+	if _, err := swaggerts.Generate(context.Background(), sources, swaggerts.Options{OutputDir: outputDir}); err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	for path, content := range files {
+		data, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(path)))
+		if err != nil {
+			t.Fatalf("read %s failed: %v", path, err)
+		}
+		if string(data) != content {
+			t.Fatalf("%s differs between Render and Generate", path)
+		}
+	}
+}
+
+func TestRender_CheckReportsDriftFromOutputDir(t *testing.T) {
+	sources := loadUsers(t)
+	outputDir := filepath.Join(t.TempDir(), "api")
+	opts := swaggerts.Options{OutputDir: outputDir, CleanOutput: true}
+	if _, err := swaggerts.Generate(context.Background(), sources, opts); err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "users", "index.ts"), []byte("stale"), 0o644); err != nil {
+		t.Fatalf("modify users/index.ts failed: %v", err)
+	}
+
+	opts.Check = true
+	files, report, err := swaggerts.Render(context.Background(), sources, opts)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if len(report.Changes) != 1 || report.Changes[0].Path != "users/index.ts" || report.Changes[0].Kind != swaggerts.ChangeChanged {
+		t.Fatalf("unexpected changes: %+v", report.Changes)
+	}
+	if files["users/index.ts"] == "stale" {
+		t.Fatalf("Render should return the generated content")
+	}
+	if data, _ := os.ReadFile(filepath.Join(outputDir, "users", "index.ts")); string(data) != "stale" {
+		t.Fatalf("Render must not write files")
+	}
+}
+
+func TestGenerate_CancelledContextWritesNothing(t *testing.T) {
+	sources := loadUsers(t)
+	outputDir := filepath.Join(t.TempDir(), "api")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := swaggerts.Generate(ctx, sources, swaggerts.Options{OutputDir: outputDir}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if _, err := os.Stat(outputDir); !os.IsNotExist(err) {
+		t.Fatalf("cancelled run should not create the output dir: %v", err)
+	}
+}
+
+func TestLoad_CancelledContextReturnsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(usersSpec))
+	}))
+	defer server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, _, err := swaggerts.Load(ctx, server.URL+"/openapi.yaml", swaggerts.LoadOptions{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestLint_ReportsUnresolvedRefsFromLenientLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	spec := strings.Replace(usersSpec, `$ref: "#/components/schemas/User"`, `$ref: "#/components/schemas/Missing"`, 1)
+	if err := os.WriteFile(path, []byte(spec), 0o644); err != nil {
+		t.Fatalf("write spec failed: %v", err)
+	}
+	doc, meta, err := swaggerts.Load(context.Background(), path, swaggerts.LoadOptions{AllowUnresolvedRefs: true})
+	if err != nil {
+		t.Fatalf("lenient Load returned error: %v", err)
+	}
+
+	findings, err := swaggerts.Lint([]swaggerts.Source{{Spec: doc, Name: meta.Source}}, swaggerts.LintOptions{
+		UnresolvedRefs: map[string][]swaggerts.UnresolvedRef{meta.Source: meta.UnresolvedRefs},
+	})
+	if err != nil {
+		t.Fatalf("Lint returned error: %v", err)
+	}
+	for _, finding := range findings {
+		if finding.Rule == swaggerts.LintRuleUnresolvedRef && finding.Severity == swaggerts.LintSeverityError {
+			return
+		}
+	}
+	t.Fatalf("missing unresolved-ref finding: %+v", findings)
+}